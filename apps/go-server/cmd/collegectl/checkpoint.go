@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/kanakkholwal/go-server/pkg/scrape"
+)
+
+// checkpoint is an NDJSON file of scrape results, appended to as results
+// arrive so an interrupted run can resume where it stopped.
+type checkpoint struct {
+	file *os.File
+	enc  *json.Encoder
+	// done holds the results that need not be scraped again
+	done map[string]scrape.ScrapeResult
+}
+
+// openCheckpoint reads the results already in path and opens it for
+// appending. Found results and roll numbers the portal does not know are
+// final; other failures are retried. A line cut short by a crash is ignored.
+func openCheckpoint(path string) (*checkpoint, error) {
+	cp := &checkpoint{done: map[string]scrape.ScrapeResult{}}
+	if f, err := os.Open(path); err == nil {
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			var res scrape.ScrapeResult
+			if json.Unmarshal(scanner.Bytes(), &res) != nil || res.RollNumber == "" {
+				continue
+			}
+			key := strings.ToUpper(res.RollNumber)
+			if res.Data != nil || strings.Contains(res.Error, scrape.RollNumberDoesNotExist.Error()) {
+				cp.done[key] = res
+			} else {
+				delete(cp.done, key)
+			}
+		}
+		f.Close()
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	cp.file = f
+	cp.enc = json.NewEncoder(f)
+	return cp, nil
+}
+
+func (cp *checkpoint) lookup(rollNo string) (scrape.ScrapeResult, bool) {
+	res, ok := cp.done[strings.ToUpper(rollNo)]
+	return res, ok
+}
+
+func (cp *checkpoint) append(res scrape.ScrapeResult) error {
+	return cp.enc.Encode(res)
+}
+
+func (cp *checkpoint) Close() error {
+	return cp.file.Close()
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kanakkholwal/go-server/types"
+	"github.com/kanakkholwal/go-server/utils"
+)
+
+// cohortFlags are the flag form of the cohort query parameters of the server.
+// List flags are comma separated.
+type cohortFlags struct {
+	batch, programme, branch, include, exclude string
+	from, to                                   int
+}
+
+func (cf *cohortFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&cf.batch, "batch", "", "batch years, e.g. 2022,2023")
+	fs.StringVar(&cf.programme, "programme", "", "programmes, e.g. btech,dual")
+	fs.StringVar(&cf.branch, "branch", "", "branches, e.g. cs,ec")
+	fs.IntVar(&cf.from, "from", 0, "first serial number")
+	fs.IntVar(&cf.to, "to", 0, "last serial number")
+	fs.StringVar(&cf.include, "include", "", "roll numbers to add")
+	fs.StringVar(&cf.exclude, "exclude", "", "roll numbers to leave out")
+}
+
+func (cf *cohortFlags) resolve() ([]string, error) {
+	sel := types.CohortSelector{
+		Programmes: splitList(cf.programme),
+		Branches:   splitList(cf.branch),
+		Include:    splitList(cf.include),
+		Exclude:    splitList(cf.exclude),
+		SerialFrom: cf.from,
+		SerialTo:   cf.to,
+	}
+	for _, b := range splitList(cf.batch) {
+		year, err := strconv.Atoi(b)
+		if err != nil {
+			return nil, fmt.Errorf("batch %q should be a valid year in YYYY format", b)
+		}
+		sel.Batches = append(sel.Batches, year)
+	}
+	if len(sel.Batches) == 0 && len(sel.Include) == 0 {
+		return nil, fmt.Errorf("--batch is required")
+	}
+	rollNumbers, err := utils.ResolveCohort(sel)
+	if err != nil {
+		return nil, err
+	}
+	if len(rollNumbers) == 0 {
+		return nil, fmt.Errorf("no roll numbers generated for the given cohort")
+	}
+	return rollNumbers, nil
+}
+
+func splitList(raw string) []string {
+	out := []string{}
+	for _, part := range strings.Split(raw, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
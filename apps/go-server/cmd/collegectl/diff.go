@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/kanakkholwal/go-server/types"
+)
+
+// Diff statuses.
+const (
+	diffAdded   = "added"
+	diffRemoved = "removed"
+	diffChanged = "changed"
+)
+
+// resultDiff lists what changed in one student's result between two files.
+type resultDiff struct {
+	RollNumber string   `json:"rollNo"`
+	Name       string   `json:"name"`
+	Status     string   `json:"status"`
+	CGPIBefore *float64 `json:"cgpiBefore,omitempty"`
+	CGPIAfter  *float64 `json:"cgpiAfter,omitempty"`
+	Changes    []string `json:"changes,omitempty"`
+}
+
+func runDiff(args []string) error {
+	fs := newFlags("diff", "<old.json> <new.json>")
+	output := outputFlag(fs, outputTable)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return fmt.Errorf("two results files are required")
+	}
+	if err := checkOutput(*output); err != nil {
+		return err
+	}
+	before, err := loadResults(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	after, err := loadResults(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	diffs := diffResults(before, after)
+	counts := map[string]int{}
+	for _, d := range diffs {
+		counts[d.Status]++
+	}
+	fmt.Fprintf(os.Stderr, "%d added, %d removed, %d changed\n", counts[diffAdded], counts[diffRemoved], counts[diffChanged])
+
+	columns := []string{"ROLL NO", "NAME", "STATUS", "CGPI", "CHANGES"}
+	return writeOutput(os.Stdout, *output, diffs, columns, func(d resultDiff) []any {
+		cgpi := ""
+		switch {
+		case d.CGPIBefore != nil && d.CGPIAfter != nil:
+			cgpi = fmt.Sprintf("%.2f -> %.2f", *d.CGPIBefore, *d.CGPIAfter)
+		case d.CGPIAfter != nil:
+			cgpi = fmt.Sprintf("%.2f", *d.CGPIAfter)
+		case d.CGPIBefore != nil:
+			cgpi = fmt.Sprintf("%.2f", *d.CGPIBefore)
+		}
+		return []any{d.RollNumber, d.Name, d.Status, cgpi, strings.Join(d.Changes, "; ")}
+	})
+}
+
+// diffResults compares two result sets by roll number. Unchanged students are
+// left out; the rest are ordered by roll number.
+func diffResults(before, after []types.StudentHtmlParsed) []resultDiff {
+	old := map[string]*types.StudentHtmlParsed{}
+	for i := range before {
+		old[strings.ToUpper(before[i].RollNumber)] = &before[i]
+	}
+	diffs := []resultDiff{}
+	for i := range after {
+		cur := &after[i]
+		key := strings.ToUpper(cur.RollNumber)
+		prev, ok := old[key]
+		delete(old, key)
+		if !ok {
+			diffs = append(diffs, resultDiff{RollNumber: cur.RollNumber, Name: cur.Name, Status: diffAdded, CGPIAfter: &cur.CGPI})
+			continue
+		}
+		if changes := diffStudent(prev, cur); len(changes) > 0 {
+			diffs = append(diffs, resultDiff{
+				RollNumber: cur.RollNumber,
+				Name:       cur.Name,
+				Status:     diffChanged,
+				CGPIBefore: &prev.CGPI,
+				CGPIAfter:  &cur.CGPI,
+				Changes:    changes,
+			})
+		}
+	}
+	for _, prev := range old {
+		diffs = append(diffs, resultDiff{RollNumber: prev.RollNumber, Name: prev.Name, Status: diffRemoved, CGPIBefore: &prev.CGPI})
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].RollNumber < diffs[j].RollNumber })
+	return diffs
+}
+
+func diffStudent(prev, cur *types.StudentHtmlParsed) []string {
+	changes := []string{}
+	if prev.Name != cur.Name {
+		changes = append(changes, fmt.Sprintf("name %q -> %q", prev.Name, cur.Name))
+	}
+	if prev.Branch != cur.Branch {
+		changes = append(changes, fmt.Sprintf("branch %s -> %s", prev.Branch, cur.Branch))
+	}
+	if prev.CGPI != cur.CGPI {
+		changes = append(changes, fmt.Sprintf("cgpi %.2f -> %.2f", prev.CGPI, cur.CGPI))
+	}
+
+	semesterKey := func(sem types.SemesterResult) string { return sem.Phase + "/" + sem.SemesterNumber }
+	oldSems := map[string]types.SemesterResult{}
+	for _, sem := range prev.SemesterResults {
+		oldSems[semesterKey(sem)] = sem
+	}
+	for _, sem := range cur.SemesterResults {
+		key := semesterKey(sem)
+		old, ok := oldSems[key]
+		delete(oldSems, key)
+		if !ok {
+			changes = append(changes, fmt.Sprintf("semester %s added", sem.SemesterNumber))
+			continue
+		}
+		if old.SGPI != sem.SGPI {
+			changes = append(changes, fmt.Sprintf("semester %s sgpi %.2f -> %.2f", sem.SemesterNumber, old.SGPI, sem.SGPI))
+		}
+		oldGrades := map[string]string{}
+		for _, subject := range old.SubjectResults {
+			oldGrades[strings.ToUpper(subject.SubjectCode)] = subject.Grade
+		}
+		for _, subject := range sem.SubjectResults {
+			code := strings.ToUpper(subject.SubjectCode)
+			grade, ok := oldGrades[code]
+			delete(oldGrades, code)
+			switch {
+			case !ok:
+				changes = append(changes, fmt.Sprintf("semester %s %s added with %s", sem.SemesterNumber, code, subject.Grade))
+			case grade != subject.Grade:
+				changes = append(changes, fmt.Sprintf("semester %s %s %s -> %s", sem.SemesterNumber, code, grade, subject.Grade))
+			}
+		}
+		removed := make([]string, 0, len(oldGrades))
+		for code := range oldGrades {
+			removed = append(removed, code)
+		}
+		sort.Strings(removed)
+		for _, code := range removed {
+			changes = append(changes, fmt.Sprintf("semester %s %s removed", sem.SemesterNumber, code))
+		}
+	}
+	removed := make([]string, 0, len(oldSems))
+	for _, sem := range oldSems {
+		removed = append(removed, sem.SemesterNumber)
+	}
+	sort.Strings(removed)
+	for _, label := range removed {
+		changes = append(changes, fmt.Sprintf("semester %s removed", label))
+	}
+	return changes
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kanakkholwal/go-server/pkg/export"
+	"github.com/kanakkholwal/go-server/types"
+)
+
+func runExport(args []string) error {
+	fs := newFlags("export", "<results.json>...")
+	format := fs.String("format", "csv", "csv, xlsx or parquet")
+	rows := fs.String("rows", export.RowsStudents, "one row per student or per course: students or courses")
+	columns := fs.String("columns", "", `columns to write, e.g. "rollNo,name:Name,cgpi"`)
+	out := fs.String("out", "", "file to write to instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return fmt.Errorf("at least one results file is required")
+	}
+	students := []types.StudentHtmlParsed{}
+	for _, path := range fs.Args() {
+		loaded, err := loadResults(path)
+		if err != nil {
+			return err
+		}
+		students = append(students, loaded...)
+	}
+	exporter, err := export.Prepare(students, export.Options{Format: *format, Rows: *rows, Columns: splitList(*columns)})
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		return exporter.Write(os.Stdout)
+	}
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	if err := exporter.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,93 @@
+// Command collegectl scrapes, re-parses, ranks, exports and compares results
+// from the command line, without running the server. It reads the same
+// environment and .env file as the server.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+
+	"github.com/joho/godotenv"
+)
+
+type command struct {
+	summary string
+	run     func(args []string) error
+}
+
+var commands = map[string]command{
+	"scrape":       {"scrape the results of the given roll numbers", runScrape},
+	"scrape-batch": {"scrape every roll number of a cohort", runScrapeBatch},
+	"gen-rolls":    {"list the roll numbers of a cohort", runGenRolls},
+	"reparse":      {"parse archived result pages again", runReparse},
+	"rank":         {"rank the students of a results file by CGPI", runRank},
+	"export":       {"export a results file as CSV, XLSX or Parquet", runExport},
+	"diff":         {"compare two results files", runDiff},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: collegectl <command> [flags] [args]")
+	fmt.Fprintln(os.Stderr)
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-13s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, `run "collegectl <command> -h" for the flags of a command`)
+}
+
+func main() {
+	godotenv.Load()
+	// the scraper logs every request; only show that with -v
+	log.SetOutput(io.Discard)
+
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		if os.Args[1] != "-h" && os.Args[1] != "--help" && os.Args[1] != "help" {
+			fmt.Fprintf(os.Stderr, "collegectl: unknown command %q\n\n", os.Args[1])
+		}
+		usage()
+		os.Exit(2)
+	}
+	if err := cmd.run(os.Args[2:]); err != nil {
+		if err == flag.ErrHelp {
+			os.Exit(2)
+		}
+		fmt.Fprintln(os.Stderr, "collegectl:", err)
+		os.Exit(1)
+	}
+}
+
+// newFlags returns the flag set of a command; parse errors are returned
+// rather than exiting so main reports them uniformly.
+func newFlags(name, args string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: collegectl %s [flags] %s\n", name, args)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// verboseFlag adds -v, which sends the scraper's log to stderr.
+func verboseFlag(fs *flag.FlagSet) *bool {
+	return fs.Bool("v", false, "log every request to stderr")
+}
+
+func applyVerbose(verbose bool) {
+	if verbose {
+		log.SetOutput(os.Stderr)
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// Output formats of every command that prints records.
+const (
+	outputJSON   = "json"
+	outputNDJSON = "ndjson"
+	outputTable  = "table"
+)
+
+func outputFlag(fs *flag.FlagSet, fallback string) *string {
+	return fs.String("o", fallback, "output format: json, ndjson or table")
+}
+
+func checkOutput(format string) error {
+	switch format {
+	case outputJSON, outputNDJSON, outputTable:
+		return nil
+	}
+	return fmt.Errorf("unknown output format %q, expected json, ndjson or table", format)
+}
+
+// writeOutput prints items as one indented JSON array, one JSON document per
+// line, or an aligned table of the given columns.
+func writeOutput[T any](w io.Writer, format string, items []T, columns []string, row func(item T) []any) error {
+	switch format {
+	case outputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if items == nil {
+			items = []T{}
+		}
+		return enc.Encode(items)
+	case outputNDJSON:
+		enc := json.NewEncoder(w)
+		for _, item := range items {
+			if err := enc.Encode(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for i, col := range columns {
+		if i > 0 {
+			fmt.Fprint(tw, "\t")
+		}
+		fmt.Fprint(tw, col)
+	}
+	fmt.Fprintln(tw)
+	for _, item := range items {
+		for i, v := range row(item) {
+			if i > 0 {
+				fmt.Fprint(tw, "\t")
+			}
+			if f, ok := v.(float64); ok {
+				v = fmt.Sprintf("%.2f", f)
+			}
+			fmt.Fprint(tw, v)
+		}
+		fmt.Fprintln(tw)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const progressWidth = 30
+
+// progress draws a progress bar on stderr when it is a terminal.
+type progress struct {
+	label   string
+	total   int
+	done    int
+	failed  int
+	start   time.Time
+	enabled bool
+}
+
+func newProgress(label string, total int, quiet bool) *progress {
+	p := &progress{label: label, total: total, start: time.Now()}
+	if info, err := os.Stderr.Stat(); err == nil && !quiet {
+		p.enabled = info.Mode()&os.ModeCharDevice != 0
+	}
+	p.draw()
+	return p
+}
+
+func (p *progress) add(failed bool) {
+	p.done++
+	if failed {
+		p.failed++
+	}
+	p.draw()
+}
+
+func (p *progress) draw() {
+	if !p.enabled || p.total == 0 {
+		return
+	}
+	filled := p.done * progressWidth / p.total
+	eta := "-"
+	if p.done > 0 {
+		remaining := time.Since(p.start) / time.Duration(p.done) * time.Duration(p.total-p.done)
+		eta = remaining.Round(time.Second).String()
+	}
+	fmt.Fprintf(os.Stderr, "\r%s [%s%s] %d/%d failed %d eta %s  ",
+		p.label, strings.Repeat("#", filled), strings.Repeat(".", progressWidth-filled),
+		p.done, p.total, p.failed, eta)
+}
+
+// finish ends the bar and prints a one line summary.
+func (p *progress) finish() {
+	if p.enabled && p.total > 0 {
+		fmt.Fprintln(os.Stderr)
+	}
+	fmt.Fprintf(os.Stderr, "%s: %d done, %d failed in %s\n", p.label, p.done, p.failed, time.Since(p.start).Round(time.Second))
+}
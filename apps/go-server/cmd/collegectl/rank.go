@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kanakkholwal/go-server/pkg/analytics"
+	"github.com/kanakkholwal/go-server/types"
+)
+
+func runRank(args []string) error {
+	fs := newFlags("rank", "<results.json>")
+	output := outputFlag(fs, outputTable)
+	top := fs.Int("top", 0, "only print the first n students")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("exactly one results file is required")
+	}
+	if err := checkOutput(*output); err != nil {
+		return err
+	}
+	students, err := loadResults(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	ranked := analytics.Ranks(students)
+	if *top > 0 && *top < len(ranked) {
+		ranked = ranked[:*top]
+	}
+	columns := []string{"COLLEGE", "BATCH", "BRANCH", "CLASS", "ROLL NO", "NAME", "PROGRAMME", "CGPI"}
+	return writeOutput(os.Stdout, *output, ranked, columns, func(s types.StudentResultWithRanks) []any {
+		return []any{s.Rank.CollegeRank, s.Rank.YearRank, s.Rank.BranchRank, s.Rank.ClassRank, s.RollNumber, s.Name, s.Programme, s.CGPI}
+	})
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kanakkholwal/go-server/pkg/scrape"
+	"github.com/kanakkholwal/go-server/utils"
+)
+
+func runReparse(args []string) error {
+	fs := newFlags("reparse", "<archive dir or .tar.gz>")
+	output := outputFlag(fs, outputJSON)
+	quiet := fs.Bool("q", false, "do not draw a progress bar")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("exactly one archive is required")
+	}
+	if err := checkOutput(*output); err != nil {
+		return err
+	}
+	pages, err := readArchive(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	rollNumbers := make([]string, 0, len(pages))
+	for roll := range pages {
+		rollNumbers = append(rollNumbers, roll)
+	}
+	sort.Strings(rollNumbers)
+
+	bar := newProgress("reparse", len(rollNumbers), *quiet)
+	results := make([]scrape.ScrapeResult, 0, len(rollNumbers))
+	for _, roll := range rollNumbers {
+		res := scrape.ScrapeResult{RollNumber: roll}
+		if student, err := scrape.ParsePages(roll, pages[roll]); err != nil {
+			res.Error = err.Error()
+		} else {
+			res.Data = student
+		}
+		results = append(results, res)
+		bar.add(res.Error != "")
+	}
+	bar.finish()
+
+	return writeOutput(os.Stdout, *output, results, resultColumns, resultRow)
+}
+
+// readArchive collects the pages saved by scrape --archive, from the
+// directory itself or from a gzipped tarball of it, grouped by roll number.
+func readArchive(path string) (map[string][]scrape.Page, error) {
+	pages := map[string][]scrape.Page{}
+	add := func(name string, r io.Reader) error {
+		roll, scheme, ok := parseArchiveName(filepath.Base(name))
+		if !ok {
+			return nil
+		}
+		html, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		source := utils.ResultSource{Scheme: scheme}
+		for _, s := range utils.GetResultSources(roll, true) {
+			if s.Scheme == scheme {
+				source = s
+			}
+		}
+		pages[roll] = append(pages[roll], scrape.Page{RollNumber: roll, Source: source, HTML: html})
+		return nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			f, err := os.Open(filepath.Join(path, entry.Name()))
+			if err != nil {
+				return nil, err
+			}
+			err = add(entry.Name(), f)
+			f.Close()
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s is neither a directory nor a .tar.gz archive: %w", path, err)
+		}
+		tr := tar.NewReader(gz)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			if hdr.Typeflag != tar.TypeReg {
+				continue
+			}
+			if err := add(hdr.Name, tr); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(pages) == 0 {
+		return nil, fmt.Errorf("no result pages (<ROLL>.<scheme>.html) found in %s", path)
+	}
+	return pages, nil
+}
+
+func parseArchiveName(name string) (roll, scheme string, ok bool) {
+	base, found := strings.CutSuffix(name, ".html")
+	if !found {
+		return "", "", false
+	}
+	roll, scheme, ok = strings.Cut(base, ".")
+	if !ok || roll == "" || scheme == "" {
+		return "", "", false
+	}
+	return strings.ToUpper(roll), scheme, true
+}
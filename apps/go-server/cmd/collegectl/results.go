@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kanakkholwal/go-server/pkg/importer"
+	"github.com/kanakkholwal/go-server/pkg/store"
+	"github.com/kanakkholwal/go-server/types"
+)
+
+// loadResults reads a results file the way POST /api/import does: a JSON
+// dump or NDJSON file of students or scrape results, such as the output or
+// checkpoint of scrape, or a CSV or XLSX sheet. Records that cannot be used,
+// including failed scrapes, are counted on stderr and left out.
+func loadResults(path string) ([]types.StudentHtmlParsed, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	target := store.NewMemory()
+	report, err := importer.Run(f, importer.Options{Format: resultsFormat(path)}, target)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if report.Skipped > 0 {
+		fmt.Fprintf(os.Stderr, "%s: skipped %d of %d records\n", path, report.Skipped, report.Rows)
+	}
+	return target.Select(nil), nil
+}
+
+func resultsFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ndjson", ".jsonl":
+		return importer.FormatNDJSON
+	case ".csv":
+		return importer.FormatCSV
+	case ".xlsx":
+		return importer.FormatXLSX
+	}
+	return importer.FormatJSON
+}
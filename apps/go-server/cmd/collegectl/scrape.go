@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kanakkholwal/go-server/config"
+	"github.com/kanakkholwal/go-server/pkg/scrape"
+	"github.com/kanakkholwal/go-server/pkg/store"
+)
+
+// scrapeFlags are shared by scrape and scrape-batch.
+type scrapeFlags struct {
+	output      *string
+	verbose     *bool
+	checkpoint  string
+	archive     string
+	concurrency int
+	delay       time.Duration
+	store       bool
+	quiet       bool
+}
+
+func (sf *scrapeFlags) register(fs *flag.FlagSet, concurrency int) {
+	sf.output = outputFlag(fs, outputJSON)
+	sf.verbose = verboseFlag(fs)
+	fs.StringVar(&sf.checkpoint, "checkpoint", "", "NDJSON file results are appended to; rerun with the same file to resume")
+	fs.StringVar(&sf.archive, "archive", "", "directory to save the raw result pages to, for reparse")
+	fs.IntVar(&sf.concurrency, "concurrency", concurrency, "parallel requests to the result portal")
+	fs.DurationVar(&sf.delay, "delay", 500*time.Millisecond, "minimum time between two requests")
+	fs.BoolVar(&sf.store, "store", false, "also save the results to the result store (RESULT_STORE_PATH)")
+	fs.BoolVar(&sf.quiet, "q", false, "do not draw a progress bar")
+}
+
+func runScrape(args []string) error {
+	fs := newFlags("scrape", "<roll>...")
+	var sf scrapeFlags
+	sf.register(fs, 5)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	rollNumbers := []string{}
+	for _, arg := range fs.Args() {
+		rollNumbers = append(rollNumbers, splitList(arg)...)
+	}
+	return scrapeRolls(rollNumbers, &sf)
+}
+
+func runScrapeBatch(args []string) error {
+	fs := newFlags("scrape-batch", "")
+	var cf cohortFlags
+	var sf scrapeFlags
+	cf.register(fs)
+	sf.register(fs, 30)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	rollNumbers, err := cf.resolve()
+	if err != nil {
+		return err
+	}
+	return scrapeRolls(rollNumbers, &sf)
+}
+
+func runGenRolls(args []string) error {
+	fs := newFlags("gen-rolls", "")
+	var cf cohortFlags
+	cf.register(fs)
+	output := outputFlag(fs, outputTable)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := checkOutput(*output); err != nil {
+		return err
+	}
+	rollNumbers, err := cf.resolve()
+	if err != nil {
+		return err
+	}
+	if *output == outputTable {
+		for _, roll := range rollNumbers {
+			fmt.Println(roll)
+		}
+		return nil
+	}
+	return writeOutput(os.Stdout, *output, rollNumbers, nil, nil)
+}
+
+// scrapeRolls scrapes the roll numbers not already in the checkpoint and
+// prints every result in the order the roll numbers were given. Ctrl-C stops
+// the run; results scraped until then are printed and kept in the checkpoint.
+func scrapeRolls(rollNumbers []string, sf *scrapeFlags) error {
+	if err := checkOutput(*sf.output); err != nil {
+		return err
+	}
+	if sf.concurrency < 1 {
+		return fmt.Errorf("--concurrency should be at least 1")
+	}
+	if sf.delay <= 0 {
+		return fmt.Errorf("--delay should be positive")
+	}
+	applyVerbose(*sf.verbose)
+
+	results := map[string]scrape.ScrapeResult{}
+	pending := rollNumbers
+	var cp *checkpoint
+	if sf.checkpoint != "" {
+		var err error
+		if cp, err = openCheckpoint(sf.checkpoint); err != nil {
+			return err
+		}
+		defer cp.Close()
+		pending = []string{}
+		for _, roll := range rollNumbers {
+			if res, ok := cp.lookup(roll); ok {
+				results[strings.ToUpper(roll)] = res
+			} else {
+				pending = append(pending, roll)
+			}
+		}
+		if len(results) > 0 {
+			fmt.Fprintf(os.Stderr, "resuming: %d of %d roll numbers already in %s\n", len(results), len(rollNumbers), sf.checkpoint)
+		}
+	}
+
+	if sf.archive != "" {
+		if err := os.MkdirAll(sf.archive, 0o755); err != nil {
+			return err
+		}
+		scrape.OnResultPage(func(page scrape.Page) {
+			if err := os.WriteFile(filepath.Join(sf.archive, archiveName(page)), page.HTML, 0o644); err != nil {
+				fmt.Fprintln(os.Stderr, "collegectl: archive:", err)
+			}
+		})
+	}
+	if sf.store {
+		path := config.Get().ResultStorePath
+		if path == "memory" {
+			return fmt.Errorf("--store needs RESULT_STORE_PATH to point to a file")
+		}
+		st, err := store.Open(path)
+		if err != nil {
+			return err
+		}
+		defer st.Close()
+		scrape.OnStudentScraped(st.Upsert)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	bar := newProgress("scrape", len(pending), sf.quiet)
+	var writeErr error
+	scrape.ScrapeEach(ctx, pending, sf.concurrency, sf.delay, func(res scrape.ScrapeResult) {
+		results[strings.ToUpper(res.RollNumber)] = res
+		if cp != nil && writeErr == nil {
+			writeErr = cp.append(res)
+		}
+		bar.add(res.Error != "")
+	})
+	bar.finish()
+	if writeErr != nil {
+		return fmt.Errorf("checkpoint: %w", writeErr)
+	}
+
+	ordered := make([]scrape.ScrapeResult, 0, len(results))
+	for _, roll := range rollNumbers {
+		if res, ok := results[strings.ToUpper(roll)]; ok {
+			ordered = append(ordered, res)
+		}
+	}
+	err := writeOutput(os.Stdout, *sf.output, ordered, resultColumns, resultRow)
+	if err != nil {
+		return err
+	}
+	if ctx.Err() != nil {
+		msg := fmt.Sprintf("interrupted after %d of %d roll numbers", len(ordered), len(rollNumbers))
+		if cp != nil {
+			msg += "; run again with the same --checkpoint to resume"
+		}
+		return fmt.Errorf("%s", msg)
+	}
+	return nil
+}
+
+var resultColumns = []string{"ROLL NO", "NAME", "BRANCH", "BATCH", "CGPI", "SEMESTERS", "ERROR"}
+
+func resultRow(res scrape.ScrapeResult) []any {
+	if res.Data == nil {
+		return []any{res.RollNumber, "", "", "", "", "", res.Error}
+	}
+	s := res.Data
+	return []any{s.RollNumber, s.Name, s.Branch, s.Batch, s.CGPI, len(s.SemesterResults), ""}
+}
+
+// archiveName is the file a result page is archived as: <ROLL>.<scheme>.html.
+func archiveName(page scrape.Page) string {
+	return fmt.Sprintf("%s.%s.html", strings.ToUpper(page.RollNumber), page.Source.Scheme)
+}
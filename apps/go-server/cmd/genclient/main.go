@@ -0,0 +1,267 @@
+// Command genclient writes the typed client of pkg/client from the OpenAPI
+// document of the routes package.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/kanakkholwal/go-server/pkg/openapi"
+	"github.com/kanakkholwal/go-server/routes"
+)
+
+func main() {
+	out := flag.String("o", "api_gen.go", "output file")
+	flag.Parse()
+
+	src, err := format.Source(generate(routes.Docs()))
+	if err != nil {
+		log.Fatalf("format generated client: %v", err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func generate(doc *openapi.Document) []byte {
+	var body strings.Builder
+	names := make([]string, 0, len(doc.Components.Schemas))
+	for name := range doc.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		writeStruct(&body, name, doc.Components.Schemas[name])
+	}
+	for _, po := range doc.Operations() {
+		writeOperation(&body, po)
+	}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by cmd/genclient from the OpenAPI document; DO NOT EDIT.\n\n")
+	b.WriteString("package client\n\nimport (\n\t\"context\"\n")
+	for _, pkg := range []string{"encoding/json", "net/url", "strconv", "time"} {
+		if strings.Contains(body.String(), pkg[strings.LastIndex(pkg, "/")+1:]+".") {
+			fmt.Fprintf(&b, "\t%q\n", pkg)
+		}
+	}
+	b.WriteString(")\n\n")
+	b.WriteString(body.String())
+	return []byte(b.String())
+}
+
+func writeStruct(b *strings.Builder, name string, s *openapi.Schema) {
+	fmt.Fprintf(b, "type %s struct {\n", name)
+	required := map[string]bool{}
+	for _, r := range s.Required {
+		required[r] = true
+	}
+	props := make([]string, 0, len(s.Properties))
+	for prop := range s.Properties {
+		props = append(props, prop)
+	}
+	sort.Strings(props)
+	for _, prop := range props {
+		typ := goType(s.Properties[prop])
+		tag := prop
+		if !required[prop] {
+			tag += ",omitempty"
+			if s.Properties[prop].Ref != "" {
+				typ = "*" + typ
+			}
+		}
+		fmt.Fprintf(b, "\t%s %s `json:%q`\n", exported(prop), typ, tag)
+	}
+	b.WriteString("}\n\n")
+}
+
+func goType(s *openapi.Schema) string {
+	if s == nil {
+		return "json.RawMessage"
+	}
+	if s.Ref != "" {
+		return s.Ref[strings.LastIndex(s.Ref, "/")+1:]
+	}
+	var typ string
+	switch s.Type {
+	case "string":
+		switch s.Format {
+		case "date-time":
+			typ = "time.Time"
+		case "byte", "binary":
+			typ = "[]byte"
+		default:
+			typ = "string"
+		}
+	case "integer":
+		typ = "int64"
+		if s.Format == "int32" {
+			typ = "int"
+		}
+	case "number":
+		typ = "float64"
+	case "boolean":
+		typ = "bool"
+	case "array":
+		typ = "[]" + goType(s.Items)
+	case "object":
+		if s.AdditionalProperties != nil {
+			typ = "map[string]" + goType(s.AdditionalProperties)
+		} else {
+			typ = "map[string]json.RawMessage"
+		}
+	default:
+		return "json.RawMessage"
+	}
+	if s.Nullable && !strings.HasPrefix(typ, "[]") && !strings.HasPrefix(typ, "map") {
+		typ = "*" + typ
+	}
+	return typ
+}
+
+func writeOperation(b *strings.Builder, po openapi.PathOperation) {
+	op := po.Operation
+	name := exported(op.OperationID)
+	args := []string{"ctx context.Context"}
+	path := fmt.Sprintf("%q", po.Path)
+	var query []openapi.Parameter
+	for _, p := range op.Parameters {
+		switch p.In {
+		case "path":
+			args = append(args, lowerFirst(exported(p.Name))+" string")
+			path = strings.Replace(path, "{"+p.Name+"}", `" + url.PathEscape(`+lowerFirst(exported(p.Name))+`) + "`, 1)
+		case "query":
+			query = append(query, p)
+		}
+	}
+	path = strings.TrimSuffix(strings.ReplaceAll(path, ` + ""`, ""), ` + ""`)
+
+	if len(query) > 0 {
+		fmt.Fprintf(b, "// %sParams are the query parameters of %s.\ntype %sParams struct {\n", name, name, name)
+		for _, p := range query {
+			fmt.Fprintf(b, "\t%s %s\n", exported(p.Name), goType(p.Schema))
+		}
+		b.WriteString("}\n\n")
+		args = append(args, "params "+name+"Params")
+	}
+	bodyArg := "nil"
+	if op.RequestBody != nil {
+		if media, ok := op.RequestBody.Content["application/json"]; ok && media.Schema.Format != "binary" {
+			args = append(args, "body "+goType(media.Schema))
+			bodyArg = "body"
+		} else {
+			// uploads are sent as is with the caller's content type
+			args = append(args, "body []byte", "contentType string")
+			bodyArg = "upload{data: body, contentType: contentType}"
+		}
+	}
+
+	result, binary := "", false
+	for status, resp := range op.Responses {
+		if !strings.HasPrefix(status, "2") {
+			continue
+		}
+		for contentType, media := range resp.Content {
+			if contentType == "application/json" {
+				result = goType(media.Schema)
+			} else {
+				binary = true
+			}
+		}
+	}
+
+	fmt.Fprintf(b, "// %s calls %s %s", name, po.Method, po.Path)
+	if op.Summary != "" {
+		fmt.Fprintf(b, ": %s", strings.ToLower(op.Summary[:1])+op.Summary[1:])
+	}
+	b.WriteString(".\n")
+	queryArg := "nil"
+	switch {
+	case binary:
+		fmt.Fprintf(b, "func (c *Client) %s(%s) ([]byte, error) {\n", name, strings.Join(args, ", "))
+	case result != "":
+		fmt.Fprintf(b, "func (c *Client) %s(%s) (%s, error) {\n\tvar out %s\n", name, strings.Join(args, ", "), result, result)
+	default:
+		fmt.Fprintf(b, "func (c *Client) %s(%s) error {\n", name, strings.Join(args, ", "))
+	}
+	if len(query) > 0 {
+		queryArg = "query"
+		b.WriteString("\tquery := url.Values{}\n")
+		for _, p := range query {
+			field := "params." + exported(p.Name)
+			switch goType(p.Schema) {
+			case "int64":
+				fmt.Fprintf(b, "\tif %s != 0 {\n\t\tquery.Set(%q, strconv.FormatInt(%s, 10))\n\t}\n", field, p.Name, field)
+			case "int":
+				fmt.Fprintf(b, "\tif %s != 0 {\n\t\tquery.Set(%q, strconv.Itoa(%s))\n\t}\n", field, p.Name, field)
+			case "float64":
+				fmt.Fprintf(b, "\tif %s != 0 {\n\t\tquery.Set(%q, strconv.FormatFloat(%s, 'f', -1, 64))\n\t}\n", field, p.Name, field)
+			case "bool":
+				fmt.Fprintf(b, "\tif %s {\n\t\tquery.Set(%q, \"true\")\n\t}\n", field, p.Name)
+			default:
+				fmt.Fprintf(b, "\tif %s != \"\" {\n\t\tquery.Set(%q, %s)\n\t}\n", field, p.Name, field)
+			}
+		}
+	}
+	switch {
+	case binary:
+		fmt.Fprintf(b, "\treturn c.do(ctx, %q, %s, %s, %s)\n}\n\n", po.Method, path, queryArg, bodyArg)
+	case result != "":
+		fmt.Fprintf(b, "\terr := c.call(ctx, %q, %s, %s, %s, &out)\n\treturn out, err\n}\n\n", po.Method, path, queryArg, bodyArg)
+	default:
+		fmt.Fprintf(b, "\treturn c.call(ctx, %q, %s, %s, %s, nil)\n}\n\n", po.Method, path, queryArg, bodyArg)
+	}
+}
+
+// initialisms are written in upper case in Go identifiers.
+var initialisms = map[string]string{"id": "ID", "cgpi": "CGPI", "sgpi": "SGPI", "url": "URL", "gpi": "GPI", "json": "JSON", "csv": "CSV"}
+
+// exported turns a JSON or operation name such as "sgpi_total" or
+// "rollNumbers" into an exported Go identifier.
+func exported(name string) string {
+	var words []string
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = nil
+		}
+	}
+	for _, r := range name {
+		switch {
+		case r == '_' || r == '-' || r == '.':
+			flush()
+		case unicode.IsUpper(r):
+			flush()
+			word = append(word, unicode.ToLower(r))
+		default:
+			word = append(word, r)
+		}
+	}
+	flush()
+	var b strings.Builder
+	for _, w := range words {
+		if up, ok := initialisms[w]; ok {
+			b.WriteString(up)
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return b.String()
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	if up, ok := initialisms[strings.ToLower(s)]; ok && up == s {
+		return strings.ToLower(s)
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
@@ -1,19 +1,27 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os/signal"
+	"syscall"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/joho/godotenv"
 
+	"github.com/kanakkholwal/go-server/config"
 	"github.com/kanakkholwal/go-server/middleware"
+	"github.com/kanakkholwal/go-server/pkg/store"
 	"github.com/kanakkholwal/go-server/routes"
 )
 
 func main() {
 	godotenv.Load()
 
-	app := fiber.New()
+	app := fiber.New(fiber.Config{
+		// result dumps posted to /api/import run to tens of megabytes
+		BodyLimit: 64 << 20,
+	})
 
 	app.Use(middleware.ErrorHandler)
 	app.Use(middleware.CustomCORS)
@@ -29,7 +37,34 @@ func main() {
 		return c.JSON(fiber.Map{"ping": "pong"})
 	})
 
+	var st *store.Bolt
+	if path := config.Get().ResultStorePath; path != "memory" {
+		var err error
+		if st, err = store.Open(path); err != nil {
+			log.Fatal(err)
+		}
+		routes.UseStore(st)
+	}
+	routes.RegisterDocRoutes(app)
 	routes.RegisterRoutes(app.Group("/api"))
 
-	log.Fatal(app.Listen("0.0.0.0:8080"))
+	// on SIGINT or SIGTERM, let the requests in flight finish so Listen
+	// returns and the store is closed cleanly
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		if err := app.Shutdown(); err != nil {
+			log.Printf("shutdown: %v", err)
+		}
+	}()
+
+	if err := app.Listen("0.0.0.0:8080"); err != nil {
+		log.Printf("listen: %v", err)
+	}
+	if st != nil {
+		if err := st.Close(); err != nil {
+			log.Printf("close result store: %v", err)
+		}
+	}
 }
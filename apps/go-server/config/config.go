@@ -0,0 +1,115 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config holds the server settings read from the environment. main loads the
+// .env file through godotenv before the first call to Get.
+type Config struct {
+	// DualDegreeCGPIRule decides how the overall CGPI of a dual degree student
+	// is derived from the bachelor and master phase CGPIs.
+	// One of "credit_weighted" (default), "latest", "bachelor" or "max".
+	DualDegreeCGPIRule string
+	// GradeScalesFile optionally points to a JSON file with per scheme grade
+	// scales that take precedence over the built-in 10 point scale.
+	GradeScalesFile string
+	// AnalyticsMinCohort is the smallest group analytics report figures for;
+	// smaller groups are suppressed so individual grades cannot be inferred.
+	AnalyticsMinCohort int
+	// ResultStorePath is the bbolt file scraped results are persisted to.
+	// "memory" keeps them in memory only.
+	ResultStorePath string
+	// ResultCacheTTL is how long a result fetched by /scrape is served without
+	// going back to the result portal.
+	ResultCacheTTL time.Duration
+	// ResultCacheSize is the most results the cache keeps in memory; the
+	// least recently used are dropped first.
+	ResultCacheSize int
+	// ResultCacheDir optionally keeps cached results on disk as well.
+	ResultCacheDir string
+	// FacultyRefreshInterval is how often the faculty directory is scraped
+	// again from the department pages; 0 leaves refreshing to the admin route.
+	FacultyRefreshInterval time.Duration
+	// HostelsCacheTTL is how long a scrape of the hostel management page is
+	// served before the page is fetched again.
+	HostelsCacheTTL time.Duration
+	// AllotmentRetention is how long a saved hostel allotment is kept; 0
+	// keeps allotments until they are deleted.
+	AllotmentRetention time.Duration
+	// AnnouncementsRefreshInterval is how often the notice, tender and event
+	// pages are checked for new items; 0 leaves refreshing to the admin route.
+	AnnouncementsRefreshInterval time.Duration
+	// UpstreamTimeout bounds every request to a college server, from dialing
+	// to reading the last byte of the answer.
+	UpstreamTimeout time.Duration
+	// UpstreamMaxBodyBytes is the largest answer read from a college server,
+	// after decompression.
+	UpstreamMaxBodyBytes int
+	// UpstreamMaxConnsPerHost caps the connections open to one college
+	// server; idle ones are kept for reuse up to the same number.
+	UpstreamMaxConnsPerHost int
+	// UpstreamProxy is an HTTP proxy URL for requests to college servers;
+	// when empty HTTP_PROXY and HTTPS_PROXY apply.
+	UpstreamProxy string
+}
+
+var (
+	once   sync.Once
+	loaded *Config
+)
+
+// Get returns the process wide configuration, reading the environment once.
+func Get() *Config {
+	once.Do(func() {
+		loaded = &Config{
+			DualDegreeCGPIRule:           strings.ToLower(getEnv("DUAL_DEGREE_CGPI_RULE", "credit_weighted")),
+			GradeScalesFile:              getEnv("GRADE_SCALES_FILE", ""),
+			AnalyticsMinCohort:           getEnvInt("ANALYTICS_MIN_COHORT", 5),
+			ResultStorePath:              getEnv("RESULT_STORE_PATH", "data/results.db"),
+			ResultCacheTTL:               getEnvDuration("RESULT_CACHE_TTL", 10*time.Minute),
+			ResultCacheSize:              getEnvInt("RESULT_CACHE_SIZE", 10000),
+			ResultCacheDir:               getEnv("RESULT_CACHE_DIR", ""),
+			FacultyRefreshInterval:       getEnvDuration("FACULTY_REFRESH_INTERVAL", 24*time.Hour),
+			HostelsCacheTTL:              getEnvDuration("HOSTELS_CACHE_TTL", 6*time.Hour),
+			AllotmentRetention:           getEnvDuration("ALLOTMENT_RETENTION", 30*24*time.Hour),
+			AnnouncementsRefreshInterval: getEnvDuration("ANNOUNCEMENTS_REFRESH_INTERVAL", time.Hour),
+			UpstreamTimeout:              getEnvDuration("UPSTREAM_TIMEOUT", 30*time.Second),
+			UpstreamMaxBodyBytes:         getEnvInt("UPSTREAM_MAX_BODY_BYTES", 8<<20),
+			UpstreamMaxConnsPerHost:      getEnvInt("UPSTREAM_MAX_CONNS_PER_HOST", 16),
+			UpstreamProxy:                getEnv("UPSTREAM_PROXY", ""),
+		}
+	})
+	return loaded
+}
+
+func getEnv(key, fallback string) string {
+	if v, ok := os.LookupEnv(key); ok && strings.TrimSpace(v) != "" {
+		return strings.TrimSpace(v)
+	}
+	return fallback
+}
+
+func getEnvInt(key string, fallback int) int {
+	v, err := strconv.Atoi(getEnv(key, ""))
+	if err != nil {
+		return fallback
+	}
+	return v
+}
+
+// getEnvDuration accepts Go durations ("90s", "10m") or plain seconds.
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	raw := getEnv(key, "")
+	if seconds, err := strconv.Atoi(raw); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if d, err := time.ParseDuration(raw); err == nil {
+		return d
+	}
+	return fallback
+}
@@ -1,17 +1,56 @@
 package constants
 
+import (
+	"slices"
+	"sort"
+)
+
+// Department is everything known about a department. DepartmentsList is the
+// single source of it: branch names, programmes and roll keys are all looked
+// up here.
 type Department struct {
-	Name         string   `json:"name"`
-	Code         string   `json:"code"`
-	Short        string   `json:"short"`
-	RollKeys     []string `json:"roll_keys"`
+	Name string `json:"name"`
+	// DisplayName is the branch name results carry for the department's
+	// students, which is not always its name.
+	DisplayName string `json:"branch"`
+	Code        string `json:"code"`
+	Short       string `json:"short"`
+	// RollKeys are the programme letter and branch code of its roll numbers,
+	// e.g. "bcs" in 22BCS001.
+	RollKeys []string `json:"roll_keys"`
+	// Programmes are the programmes of ProgrammeKeys it admits students
+	// to, derived from RollKeys when the package is initialised.
+	Programmes   []string `json:"programmes"`
 	CoursePrefix string   `json:"course_prefix"`
 	Page         string   `json:"page_url"`
 }
 
+func init() {
+	for i := range DepartmentsList {
+		DepartmentsList[i].Programmes = programmesOf(DepartmentsList[i].RollKeys)
+	}
+}
+
+// programmesOf lists the programmes of ProgrammeKeys the roll keys belong
+// to, sorted, e.g. B.Tech and M.Tech for "bcs" and "mcs".
+func programmesOf(rollKeys []string) []string {
+	programmes := []string{}
+	for programme, keys := range ProgrammeKeys {
+		for _, key := range rollKeys {
+			if slices.Contains(keys, key) {
+				programmes = append(programmes, programme)
+				break
+			}
+		}
+	}
+	sort.Strings(programmes)
+	return programmes
+}
+
 var DepartmentsList = []Department{
 	{
 		Name:         "Computer Science and Engineering",
+		DisplayName:  "Computer Science and Engineering",
 		Code:         "cse",
 		Short:        "CSE",
 		RollKeys:     []string{"bcs", "dcs", "mcs"},
@@ -20,6 +59,7 @@ var DepartmentsList = []Department{
 	},
 	{
 		Name:         "Electronics and Communication Engineering",
+		DisplayName:  "Electronics and Communication Engineering",
 		Code:         "ece",
 		Short:        "ECE",
 		RollKeys:     []string{"bec", "dec", "mec"},
@@ -28,6 +68,7 @@ var DepartmentsList = []Department{
 	},
 	{
 		Name:         "Electrical Engineering",
+		DisplayName:  "Electrical Engineering",
 		Code:         "ee",
 		Short:        "EE",
 		RollKeys:     []string{"bee", "mee"},
@@ -36,6 +77,7 @@ var DepartmentsList = []Department{
 	},
 	{
 		Name:         "Mechanical Engineering",
+		DisplayName:  "Mechanical Engineering",
 		Code:         "me",
 		Short:        "ME",
 		RollKeys:     []string{"bme", "mme"},
@@ -44,6 +86,7 @@ var DepartmentsList = []Department{
 	},
 	{
 		Name:         "Civil Engineering",
+		DisplayName:  "Civil Engineering",
 		Code:         "ce",
 		Short:        "CE",
 		RollKeys:     []string{"bce", "mce"},
@@ -52,6 +95,7 @@ var DepartmentsList = []Department{
 	},
 	{
 		Name:         "Chemical Engineering",
+		DisplayName:  "Chemical Engineering",
 		Code:         "che",
 		Short:        "CHE",
 		RollKeys:     []string{"bch", "mch"},
@@ -60,6 +104,7 @@ var DepartmentsList = []Department{
 	},
 	{
 		Name:         "Materials Science and Engineering",
+		DisplayName:  "Materials Science and Engineering",
 		Code:         "mse",
 		Short:        "MSE",
 		RollKeys:     []string{"bms", "mms"},
@@ -68,6 +113,7 @@ var DepartmentsList = []Department{
 	},
 	{
 		Name:         "Mathematics & Scientific Computing",
+		DisplayName:  "Mathematics and Computing",
 		Code:         "mnc",
 		Short:        "MNC",
 		RollKeys:     []string{"bma", "mma"},
@@ -76,6 +122,7 @@ var DepartmentsList = []Department{
 	},
 	{
 		Name:         "Architecture",
+		DisplayName:  "Architecture",
 		Code:         "arc",
 		Short:        "ARC",
 		RollKeys:     []string{"bar", "mar"},
@@ -84,6 +131,7 @@ var DepartmentsList = []Department{
 	},
 	{
 		Name:         "Engineering Physics",
+		DisplayName:  "Engineering Physics",
 		Code:         "phy",
 		Short:        "PHY",
 		RollKeys:     []string{"bph", "mph"},
@@ -1,28 +1,38 @@
 package constants
 
+// FirstBatchYear and LastBatchYear bound the batch years accepted by the API.
+const (
+	FirstBatchYear = 2020
+	LastBatchYear  = 2100
+)
+
 var ProgrammeKeys = map[string][]string{
 	"B.Tech":      {"bce", "bme", "bms", "bma", "bph", "bee", "bec", "bcs", "bch"},
 	"B.Arch":      {"bar"},
 	"Dual Degree": {"dcs", "dec"},
 	"M.Tech":      {"mce", "mme", "mms", "mma", "mph", "mee", "mec", "mcs", "mch"},
+	"M.Arch":      {"mar"},
 }
 
 var SchemeKeys = map[string]string{
 	"B.Tech":      "scheme",
 	"M.Tech":      "mtech",
 	"Dual Degree": "dualdegree",
+	"M.Arch":      "mtech",
 }
 
-var BranchCodesToNames = map[string]string{
-	"ar": "Architecture",
-	"ce": "Civil Engineering",
-	"me": "Mechanical Engineering",
-	"ms": "Materials Science and Engineering",
-	"ma": "Mathematics and Computing",
-	"ph": "Engineering Physics",
-	"ee": "Electrical Engineering",
-	"ec": "Electronics and Communication Engineering",
-	"cs": "Computer Science and Engineering",
+// BranchCodesToNames maps the two letter branch code of a roll number ("cs")
+// to the branch name results carry. It is derived from DepartmentsList.
+var BranchCodesToNames = branchCodesToNames()
+
+func branchCodesToNames() map[string]string {
+	names := map[string]string{}
+	for _, department := range DepartmentsList {
+		for _, key := range department.RollKeys {
+			names[key[1:]] = department.DisplayName
+		}
+	}
+	return names
 }
 
 var ThresholdForProgramme = map[string]int{
@@ -30,6 +40,7 @@ var ThresholdForProgramme = map[string]int{
 	"B.Arch":      60,
 	"M.Tech":      40,
 	"Dual Degree": 30,
+	"M.Arch":      20,
 }
 
 type HeaderInfo struct {
@@ -47,8 +47,9 @@ func CustomCORS(c *fiber.Ctx) error {
 
 	if allowed {
 		c.Set("Access-Control-Allow-Origin", origin)
-		c.Set("Access-Control-Allow-Methods", "GET,POST,OPTIONS")
-		c.Set("Access-Control-Allow-Headers", "Content-Type,X-Authorization")
+		c.Set("Access-Control-Allow-Methods", "GET,POST,DELETE,OPTIONS")
+		c.Set("Access-Control-Allow-Headers", "Content-Type,X-Authorization,Cache-Control,If-None-Match")
+		c.Set("Access-Control-Expose-Headers", "ETag,Last-Modified,Age,X-Cache")
 		c.Set("Access-Control-Allow-Credentials", "true")
 
 		if c.Method() == fiber.MethodOptions {
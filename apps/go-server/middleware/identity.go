@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"os"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequireServerIdentity only lets through requests that carry the
+// SERVER_IDENTITY as X-Authorization. CustomCORS also admits browsers on the
+// allowed origins; admin routes use this to shut them out.
+func RequireServerIdentity(c *fiber.Ctx) error {
+	serverIdentity := os.Getenv("SERVER_IDENTITY")
+	if serverIdentity == "" || c.Get("X-Authorization") != serverIdentity {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "this route requires the server identity"})
+	}
+	return c.Next()
+}
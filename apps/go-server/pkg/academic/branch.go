@@ -0,0 +1,86 @@
+package academic
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/kanakkholwal/go-server/types"
+	"github.com/kanakkholwal/go-server/utils"
+)
+
+const (
+	// branchChangeAfterSemester is the last semester of the common first year;
+	// only courses from later semesters say anything about the branch.
+	branchChangeAfterSemester = 2
+	// MinBranchCourses is the number of departmental courses needed before a
+	// branch change is reported.
+	MinBranchCourses = 4
+	// MinBranchConfidence is the share of departmental courses that must belong
+	// to another department before a branch change is reported.
+	MinBranchConfidence = 0.6
+)
+
+// InferBranch counts the department course prefixes of every course taken
+// after the first year and treats the dominant department as the branch the
+// student is currently in. Confidence is the share of counted courses that
+// belong to the reported current branch.
+func InferBranch(student *types.StudentHtmlParsed) types.BranchInference {
+	inference := types.BranchInference{}
+	if student == nil {
+		return inference
+	}
+	inference.OriginalBranch = utils.DetermineDepartment(student.RollNumber)
+	if department, ok := utils.DepartmentForRollNumber(student.RollNumber); ok {
+		inference.OriginalBranchCode = department.Code
+	}
+	inference.CurrentBranch = inference.OriginalBranch
+	inference.CurrentBranchCode = inference.OriginalBranchCode
+
+	counts := map[string]int{}
+	for i, sem := range student.SemesterResults {
+		number, err := strconv.Atoi(sem.SemesterNumber)
+		if err != nil {
+			number = i + 1
+		}
+		if number <= branchChangeAfterSemester {
+			continue
+		}
+		for _, subject := range sem.SubjectResults {
+			department, ok := utils.DepartmentForCoursePrefix(subject.SubjectCode)
+			if !ok {
+				continue
+			}
+			counts[department.CoursePrefix]++
+			inference.CoursesConsidered++
+		}
+	}
+	if inference.CoursesConsidered == 0 {
+		return inference
+	}
+	inference.PrefixCounts = counts
+
+	dominant, best := "", 0
+	for prefix, count := range counts {
+		if count > best || (count == best && prefix < dominant) {
+			dominant, best = prefix, count
+		}
+	}
+	department, _ := utils.DepartmentForCoursePrefix(dominant)
+	share := func(count int) float64 {
+		return math.Round(float64(count)/float64(inference.CoursesConsidered)*100) / 100
+	}
+
+	if department.Code != inference.OriginalBranchCode &&
+		inference.CoursesConsidered >= MinBranchCourses && share(best) >= MinBranchConfidence {
+		inference.CurrentBranch = department.DisplayName
+		inference.CurrentBranchCode = department.Code
+		inference.Changed = true
+		inference.Confidence = share(best)
+		return inference
+	}
+	// no change detected: report how strongly the courses support the original branch
+	if original, ok := utils.DepartmentForRollNumber(student.RollNumber); ok {
+		inference.Confidence = share(counts[original.CoursePrefix])
+	}
+	return inference
+}
@@ -0,0 +1,86 @@
+package academic
+
+import (
+	"strings"
+
+	"github.com/kanakkholwal/go-server/pkg/grades"
+	"github.com/kanakkholwal/go-server/types"
+)
+
+// Analyze walks the semesters of a student in order and records every course
+// that received a failing grade on the grade scale of the semester's scheme.
+// A failed course is considered cleared when the same course code shows up
+// again in a later semester with a passing grade; otherwise its credits count
+// as outstanding.
+func Analyze(student *types.StudentHtmlParsed) types.AcademicStatus {
+	status := types.AcademicStatus{Status: types.StatusClear, Backlogs: []types.BacklogCourse{}}
+	if student == nil {
+		return status
+	}
+	open := map[string]int{} // course code => index into status.Backlogs while uncleared
+
+	for _, sem := range student.SemesterResults {
+		scale := grades.ForScheme(sem.Scheme)
+		for _, subject := range sem.SubjectResults {
+			code := normaliseCode(subject.SubjectCode)
+			if code == "" {
+				continue
+			}
+			if scale.IsFailing(subject.Grade) {
+				i, ok := open[code]
+				if !ok {
+					i = len(status.Backlogs)
+					open[code] = i
+					status.Backlogs = append(status.Backlogs, types.BacklogCourse{
+						Code:   subject.SubjectCode,
+						Name:   subject.SubjectName,
+						Credit: subject.Credit,
+					})
+				}
+				status.Backlogs[i].FailedIn = append(status.Backlogs[i].FailedIn, sem.SemesterNumber)
+				status.Backlogs[i].FailGrades = append(status.Backlogs[i].FailGrades, strings.ToUpper(strings.TrimSpace(subject.Grade)))
+				continue
+			}
+			if i, ok := open[code]; ok {
+				status.Backlogs[i].Cleared = true
+				status.Backlogs[i].ClearedIn = sem.SemesterNumber
+				status.Backlogs[i].ClearedGrade = subject.Grade
+				delete(open, code)
+			}
+		}
+	}
+
+	for _, backlog := range status.Backlogs {
+		if backlog.Cleared {
+			status.ClearedBacklogs++
+		} else {
+			status.ActiveBacklogs++
+			status.OutstandingCredits += backlog.Credit
+		}
+	}
+	switch {
+	case status.ActiveBacklogs > 0:
+		status.Status = types.StatusActiveBacklog
+	case status.ClearedBacklogs > 0:
+		status.Status = types.StatusClearedBacklog
+	}
+	return status
+}
+
+// MatchesStatus reports whether an analysed status passes a filter value.
+// "any_backlog" matches both active and cleared backlogs; an empty filter
+// matches everything.
+func MatchesStatus(status types.AcademicStatus, filter string) bool {
+	switch filter {
+	case "":
+		return true
+	case "any_backlog":
+		return status.Status != types.StatusClear
+	default:
+		return status.Status == filter
+	}
+}
+
+func normaliseCode(code string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), " ", ""))
+}
@@ -0,0 +1,459 @@
+// Package allotment assigns students from an uploaded sheet to hostel rooms.
+// The allocation is deterministic: the same sheet, rooms and rules always
+// produce the same rooms, and every placement carries the reason for it.
+package allotment
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kanakkholwal/go-server/pkg/importer"
+)
+
+// Fields a sheet column can be mapped to.
+const (
+	FieldRollNo     = "rollNo"
+	FieldName       = "name"
+	FieldGender     = "gender"
+	FieldSOE        = "soe"
+	FieldProgramme  = "programme"
+	FieldBranch     = "branch"
+	FieldYear       = "year"
+	FieldBatch      = "batch"
+	FieldCGPI       = "cgpi"
+	FieldFatherName = "fatherName"
+	FieldMotherName = "motherName"
+)
+
+// headerAliases are the header names each field is recognised by when no
+// mapping is given, compared with importer.Squash.
+var headerAliases = map[string][]string{
+	FieldRollNo:     {"rollno", "rollnumber", "roll", "enrollmentno", "enrolmentno"},
+	FieldName:       {"name", "studentname", "nameofstudent", "fullname"},
+	FieldGender:     {"gender", "sex"},
+	FieldSOE:        {"soe", "stateofeligibility", "quota"},
+	FieldProgramme:  {"programme", "program", "course", "degree"},
+	FieldBranch:     {"branch", "department", "discipline"},
+	FieldYear:       {"year", "yearofstudy", "studyyear", "currentyear"},
+	FieldBatch:      {"batch", "batchyear", "admissionyear"},
+	FieldCGPI:       {"cgpi", "cgpa"},
+	FieldFatherName: {"fathername", "fathersname"},
+	FieldMotherName: {"mothername", "mothersname"},
+}
+
+// Priority keys of Rules.Priority.
+const (
+	PriorityYear   = "year"
+	PriorityCGPI   = "cgpi"
+	PrioritySOE    = "soe"
+	PriorityRollNo = "rollNo"
+)
+
+// MaxYearOfStudy is the last year of the longest programme, dual degree.
+const MaxYearOfStudy = 5
+
+// DefaultPriority ranks seniors first, then by CGPI.
+var DefaultPriority = []string{PriorityYear, PriorityCGPI}
+
+// Rules decide who is allotted and how rooms are filled.
+type Rules struct {
+	// Gender limits the allotment to one gender, e.g. "male" for a boys
+	// hostel; other students are left out.
+	Gender string `json:"gender,omitempty"`
+	// SOEPriority is the state of eligibility, e.g. "Home State", of which
+	// one student is placed in every room before the rooms are filled.
+	SOEPriority string `json:"soePriority,omitempty"`
+	// Priority orders students when there are fewer seats than students and
+	// decides the filling order: year (seniors first: the higher year of
+	// study, or else the earlier batch), cgpi (highest first), soe
+	// (SOEPriority first) or rollNo. A "-" prefix reverses a key. Ties always
+	// fall back to the roll number.
+	Priority []string `json:"priority,omitempty"`
+	// GroupBy keeps students of the same branch, year, batch or programme in
+	// neighbouring rooms.
+	GroupBy string `json:"groupBy,omitempty"`
+}
+
+// An inventory may hold at most MaxRooms rooms and MaxSeats seats in all,
+// well above any hostel, so a mistyped distribution cannot exhaust memory.
+const (
+	MaxRooms = 5000
+	MaxSeats = 20000
+)
+
+// Room is one room of the inventory.
+type Room struct {
+	ID       string `json:"id"`
+	Block    string `json:"block,omitempty"`
+	Capacity int    `json:"capacity"`
+}
+
+// RoomsFromDistribution builds an inventory from room counts per capacity,
+// e.g. {2: 40, 3: 10}, as the Node allotment took it. Rooms are numbered
+// R001, R002, ... from the smallest capacity up.
+func RoomsFromDistribution(distribution map[int]int) []Room {
+	capacities := make([]int, 0, len(distribution))
+	for capacity := range distribution {
+		capacities = append(capacities, capacity)
+	}
+	sort.Ints(capacities)
+	rooms := []Room{}
+	for _, capacity := range capacities {
+		for range distribution[capacity] {
+			rooms = append(rooms, Room{ID: fmt.Sprintf("R%03d", len(rooms)+1), Capacity: capacity})
+		}
+	}
+	return rooms
+}
+
+// Student is one row of the sheet. Row is the 1-based sheet row.
+type Student struct {
+	Row        int    `json:"row"`
+	RollNumber string `json:"rollNo"`
+	Name       string `json:"name"`
+	Gender     string `json:"gender"`
+	SOE        string `json:"soe,omitempty"`
+	Programme  string `json:"programme,omitempty"`
+	Branch     string `json:"branch,omitempty"`
+	// Year is the year of study, 1 for freshers; Batch is the year of
+	// admission, lower for seniors.
+	Year  int     `json:"year,omitempty"`
+	Batch int     `json:"batch,omitempty"`
+	CGPI  float64 `json:"cgpi"`
+	// CGPISource is "store" when the CGPI came from the result store, "sheet"
+	// when from the sheet, and empty when neither had one.
+	CGPISource string            `json:"cgpiSource,omitempty"`
+	FatherName string            `json:"fatherName,omitempty"`
+	MotherName string            `json:"motherName,omitempty"`
+	Extra      map[string]string `json:"extra,omitempty"`
+}
+
+// Request is everything an allotment is computed from.
+type Request struct {
+	// Rows is the sheet, header first.
+	Rows [][]string
+	// Mapping maps fields to a header or a 1-based column number; unmapped
+	// fields are found by their usual header names.
+	Mapping map[string]string
+	// ExtraFields are further headers copied to the output.
+	ExtraFields []string
+	Rooms       []Room
+	Rules       Rules
+}
+
+// CGPILookup returns the CGPI on record for a roll number.
+type CGPILookup func(rollNo string) (float64, bool)
+
+// Seat is a student placed in a room. Seat numbers start at 1.
+type Seat struct {
+	Student
+	Rank   int    `json:"rank"`
+	Seat   int    `json:"seat"`
+	Reason string `json:"reason"`
+}
+
+type RoomAllocation struct {
+	Room
+	Students []Seat `json:"students"`
+	Free     int    `json:"free"`
+}
+
+// Unallocated is a student left without a room, or a row that could not be
+// read, and why.
+type Unallocated struct {
+	Student
+	Reason string `json:"reason"`
+}
+
+// LogEntry records one decision of the allotment, in the order taken.
+type LogEntry struct {
+	RollNumber string `json:"rollNo,omitempty"`
+	Room       string `json:"room,omitempty"`
+	Message    string `json:"message"`
+}
+
+type Summary struct {
+	Rows        int `json:"rows"`
+	Eligible    int `json:"eligible"`
+	Allocated   int `json:"allocated"`
+	Unallocated int `json:"unallocated"`
+	Rooms       int `json:"rooms"`
+	Seats       int `json:"seats"`
+	FreeSeats   int `json:"freeSeats"`
+}
+
+type Allocation struct {
+	Rules       Rules            `json:"rules"`
+	ExtraFields []string         `json:"extraFields,omitempty"`
+	Summary     Summary          `json:"summary"`
+	Rooms       []RoomAllocation `json:"rooms"`
+	Unallocated []Unallocated    `json:"unallocated"`
+	Log         []LogEntry       `json:"log"`
+}
+
+// Allot reads the students of the sheet and places them in the rooms.
+// Students are ranked by the priority rules; when seats run out the lowest
+// ranked are unallocated. With an SOE priority, one student of that SOE is
+// placed in every room first, in rank order; the remaining students then
+// fill the rooms in order, grouped by GroupBy when set.
+func Allot(req Request, lookup CGPILookup) (*Allocation, error) {
+	rules, err := checkRules(req.Rules)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRooms(req.Rooms); err != nil {
+		return nil, err
+	}
+	alloc := &Allocation{Rules: rules, ExtraFields: req.ExtraFields, Rooms: []RoomAllocation{}, Unallocated: []Unallocated{}, Log: []LogEntry{}}
+	logf := func(roll, room, format string, args ...any) {
+		alloc.Log = append(alloc.Log, LogEntry{RollNumber: roll, Room: room, Message: fmt.Sprintf(format, args...)})
+	}
+
+	students, rejected, err := readStudents(req, lookup)
+	if err != nil {
+		return nil, err
+	}
+	alloc.Summary.Rows = len(students) + len(rejected)
+	for _, u := range rejected {
+		alloc.Unallocated = append(alloc.Unallocated, u)
+		logf(u.RollNumber, "", "row %d rejected: %s", u.Row, u.Reason)
+	}
+
+	eligible := []Student{}
+	for _, s := range students {
+		if rules.Gender != "" && s.Gender != rules.Gender {
+			logf(s.RollNumber, "", "left out: gender %s, allotting %s", s.Gender, rules.Gender)
+			continue
+		}
+		eligible = append(eligible, s)
+	}
+	alloc.Summary.Eligible = len(eligible)
+
+	less := rankOrder(rules)
+	sort.SliceStable(eligible, func(i, j int) bool { return less(&eligible[i], &eligible[j]) })
+	ranks := make(map[string]int, len(eligible))
+	for i, s := range eligible {
+		ranks[s.RollNumber] = i + 1
+	}
+	priority := strings.Join(rules.Priority, ", ")
+
+	seats := 0
+	for _, room := range req.Rooms {
+		seats += room.Capacity
+		alloc.Rooms = append(alloc.Rooms, RoomAllocation{Room: room, Students: []Seat{}})
+	}
+	alloc.Summary.Rooms, alloc.Summary.Seats = len(req.Rooms), seats
+
+	admitted := eligible
+	if len(eligible) > seats {
+		admitted = eligible[:seats]
+		for _, s := range eligible[seats:] {
+			reason := fmt.Sprintf("no seat left: ranked %d of %d by %s for %d seats", ranks[s.RollNumber], len(eligible), priority, seats)
+			alloc.Unallocated = append(alloc.Unallocated, Unallocated{Student: s, Reason: reason})
+			logf(s.RollNumber, "", "%s", reason)
+		}
+	}
+	sequence := groupSequence(admitted, rules.GroupBy)
+
+	place := func(ra *RoomAllocation, s Student, reason string) {
+		ra.Students = append(ra.Students, Seat{Student: s, Rank: ranks[s.RollNumber], Seat: len(ra.Students) + 1, Reason: reason})
+		logf(s.RollNumber, ra.ID, "seat %d of %d: %s", len(ra.Students), ra.Capacity, reason)
+	}
+	placed := map[string]bool{}
+	if rules.SOEPriority != "" {
+		r := 0
+		for _, s := range sequence {
+			if r == len(alloc.Rooms) {
+				break
+			}
+			if !strings.EqualFold(s.SOE, rules.SOEPriority) {
+				continue
+			}
+			place(&alloc.Rooms[r], s, fmt.Sprintf("rank %d by %s; one %s student per room first", ranks[s.RollNumber], priority, rules.SOEPriority))
+			placed[s.RollNumber] = true
+			r++
+		}
+		if r < len(alloc.Rooms) {
+			logf("", "", "only %d %s students for %d rooms", r, rules.SOEPriority, len(alloc.Rooms))
+		}
+	}
+	r := 0
+	for _, s := range sequence {
+		if placed[s.RollNumber] {
+			continue
+		}
+		for alloc.Rooms[r].Capacity == len(alloc.Rooms[r].Students) {
+			r++
+		}
+		reason := fmt.Sprintf("rank %d by %s", ranks[s.RollNumber], priority)
+		if rules.GroupBy != "" {
+			reason += fmt.Sprintf(", grouped by %s %s", rules.GroupBy, groupKey(s, rules.GroupBy))
+		}
+		place(&alloc.Rooms[r], s, reason)
+	}
+
+	for i := range alloc.Rooms {
+		ra := &alloc.Rooms[i]
+		ra.Free = ra.Capacity - len(ra.Students)
+		alloc.Summary.Allocated += len(ra.Students)
+		alloc.Summary.FreeSeats += ra.Free
+	}
+	alloc.Summary.Unallocated = len(alloc.Unallocated)
+	return alloc, nil
+}
+
+func checkRules(rules Rules) (Rules, error) {
+	if rules.Gender != "" {
+		gender, err := importer.NormalizeGender(rules.Gender)
+		if err != nil {
+			return rules, err
+		}
+		rules.Gender = gender
+	}
+	if len(rules.Priority) == 0 {
+		rules.Priority = DefaultPriority
+	}
+	for _, key := range rules.Priority {
+		switch strings.TrimPrefix(key, "-") {
+		case PriorityYear, PriorityCGPI, PrioritySOE, PriorityRollNo:
+		default:
+			return rules, fmt.Errorf("unknown priority %q, expected year, cgpi, soe or rollNo", key)
+		}
+	}
+	switch rules.GroupBy {
+	case "", FieldBranch, FieldYear, FieldBatch, FieldProgramme:
+	default:
+		return rules, fmt.Errorf("unknown groupBy %q, expected branch, year, batch or programme", rules.GroupBy)
+	}
+	return rules, nil
+}
+
+func checkRooms(rooms []Room) error {
+	if len(rooms) == 0 {
+		return fmt.Errorf("no rooms to allot")
+	}
+	if len(rooms) > MaxRooms {
+		return fmt.Errorf("%d rooms is more than the %d an allotment takes", len(rooms), MaxRooms)
+	}
+	seen := map[string]bool{}
+	seats := 0
+	for _, room := range rooms {
+		if room.ID == "" {
+			return fmt.Errorf("every room needs an id")
+		}
+		if seen[room.ID] {
+			return fmt.Errorf("room %s is listed twice", room.ID)
+		}
+		seen[room.ID] = true
+		if room.Capacity < 1 {
+			return fmt.Errorf("room %s needs a capacity of at least 1", room.ID)
+		}
+		if seats += room.Capacity; room.Capacity > MaxSeats || seats > MaxSeats {
+			return fmt.Errorf("the rooms hold more than the %d seats an allotment takes", MaxSeats)
+		}
+	}
+	return nil
+}
+
+// rankOrder compares students by the priority keys, then by roll number.
+func rankOrder(rules Rules) func(a, b *Student) bool {
+	return func(a, b *Student) bool {
+		for _, key := range rules.Priority {
+			desc := strings.HasPrefix(key, "-")
+			var c int
+			switch strings.TrimPrefix(key, "-") {
+			case PriorityYear:
+				// the year of study decides, then the batch; a student
+				// missing one ranks last either way
+				switch {
+				case a.Year != b.Year && (a.Year == 0 || b.Year == 0):
+					return b.Year == 0
+				case a.Year != b.Year:
+					c = -cmpInt(a.Year, b.Year)
+				case a.Batch != b.Batch && (a.Batch == 0 || b.Batch == 0):
+					return b.Batch == 0
+				default:
+					c = cmpInt(a.Batch, b.Batch)
+				}
+			case PriorityCGPI:
+				c = -cmpFloat(a.CGPI, b.CGPI)
+			case PrioritySOE:
+				c = -cmpBool(strings.EqualFold(a.SOE, rules.SOEPriority), strings.EqualFold(b.SOE, rules.SOEPriority))
+			case PriorityRollNo:
+				c = strings.Compare(a.RollNumber, b.RollNumber)
+			}
+			if desc {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return a.RollNumber < b.RollNumber
+	}
+}
+
+// groupSequence orders ranked students group by group, groups in the order of
+// their best ranked member, keeping the rank order inside a group.
+func groupSequence(ranked []Student, groupBy string) []Student {
+	if groupBy == "" {
+		return ranked
+	}
+	first := map[string]int{}
+	for i, s := range ranked {
+		if _, ok := first[groupKey(s, groupBy)]; !ok {
+			first[groupKey(s, groupBy)] = i
+		}
+	}
+	sequence := append([]Student(nil), ranked...)
+	sort.SliceStable(sequence, func(i, j int) bool {
+		return first[groupKey(sequence[i], groupBy)] < first[groupKey(sequence[j], groupBy)]
+	})
+	return sequence
+}
+
+func groupKey(s Student, groupBy string) string {
+	switch groupBy {
+	case FieldBranch:
+		return s.Branch
+	case FieldYear:
+		return strconv.Itoa(s.Year)
+	case FieldBatch:
+		return strconv.Itoa(s.Batch)
+	case FieldProgramme:
+		return s.Programme
+	}
+	return ""
+}
+
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func cmpFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func cmpBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	}
+	return -1
+}
@@ -0,0 +1,147 @@
+package allotment
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Output formats of Write.
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// Write writes one row per student: the allotted students room by room, then
+// the unallocated ones with an empty room. XLSX puts the unallocated students
+// on a sheet of their own.
+func Write(w io.Writer, format string, alloc *Allocation) error {
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, alloc)
+	case FormatXLSX:
+		return writeXLSX(w, alloc)
+	}
+	return fmt.Errorf("unknown format %q, expected csv or xlsx", format)
+}
+
+func (a *Allocation) headers() []string {
+	headers := []string{"Room", "Block", "Capacity", "Seat", "Rank", "Roll No", "Name", "Gender", "SOE", "Programme", "Branch", "Year", "Batch", "CGPI", "CGPI Source", "Father Name", "Mother Name"}
+	headers = append(headers, a.ExtraFields...)
+	return append(headers, "Reason")
+}
+
+func (a *Allocation) row(room *Room, seat, rank int, s *Student, reason string) []string {
+	values := make([]string, 0, len(a.ExtraFields)+18)
+	if room != nil {
+		values = append(values, room.ID, room.Block, strconv.Itoa(room.Capacity), strconv.Itoa(seat))
+	} else {
+		values = append(values, "", "", "", "")
+	}
+	values = append(values, number(rank), s.RollNumber, s.Name, s.Gender, s.SOE, s.Programme, s.Branch, number(s.Year), number(s.Batch))
+	cgpi := ""
+	if s.CGPISource != "" {
+		cgpi = strconv.FormatFloat(s.CGPI, 'f', -1, 64)
+	}
+	values = append(values, cgpi, s.CGPISource, s.FatherName, s.MotherName)
+	for _, name := range a.ExtraFields {
+		values = append(values, s.Extra[name])
+	}
+	return append(values, reason)
+}
+
+// number leaves zero, meaning unknown, blank.
+func number(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+// eachRow calls fn for every allotted student, then every unallocated one.
+func (a *Allocation) eachRow(fn func(allotted bool, values []string) error) error {
+	for i := range a.Rooms {
+		room := &a.Rooms[i]
+		for j := range room.Students {
+			seat := &room.Students[j]
+			if err := fn(true, a.row(&room.Room, seat.Seat, seat.Rank, &seat.Student, seat.Reason)); err != nil {
+				return err
+			}
+		}
+	}
+	for i := range a.Unallocated {
+		u := &a.Unallocated[i]
+		if err := fn(false, a.row(nil, 0, 0, &u.Student, u.Reason)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeCSV(w io.Writer, alloc *Allocation) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(alloc.headers()); err != nil {
+		return err
+	}
+	err := alloc.eachRow(func(_ bool, values []string) error {
+		return out.Write(values)
+	})
+	if err != nil {
+		return err
+	}
+	out.Flush()
+	return out.Error()
+}
+
+func writeXLSX(w io.Writer, alloc *Allocation) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	const allotted, unallocated = "Allotment", "Unallocated"
+	if err := f.SetSheetName(f.GetSheetName(0), allotted); err != nil {
+		return err
+	}
+	if _, err := f.NewSheet(unallocated); err != nil {
+		return err
+	}
+	headers := alloc.headers()
+	next := map[string]int{allotted: 1, unallocated: 1}
+	setRow := func(sheet string, values []string) error {
+		cell, err := excelize.CoordinatesToCellName(1, next[sheet])
+		if err != nil {
+			return err
+		}
+		next[sheet]++
+		row := make([]any, len(values))
+		for i, v := range values {
+			row[i] = v
+		}
+		return f.SetSheetRow(sheet, cell, &row)
+	}
+	for _, sheet := range []string{allotted, unallocated} {
+		if err := setRow(sheet, headers); err != nil {
+			return err
+		}
+	}
+	err := alloc.eachRow(func(isAllotted bool, values []string) error {
+		if isAllotted {
+			return setRow(allotted, values)
+		}
+		return setRow(unallocated, values)
+	})
+	if err != nil {
+		return err
+	}
+	return f.Write(w)
+}
+
+// ContentType is the media type of a Write format.
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
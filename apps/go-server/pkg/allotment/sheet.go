@@ -0,0 +1,179 @@
+package allotment
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kanakkholwal/go-server/pkg/importer"
+)
+
+// readStudents turns the sheet into students. Rows that cannot be read or
+// repeat a roll number come back as unallocated with the reason.
+func readStudents(req Request, lookup CGPILookup) ([]Student, []Unallocated, error) {
+	if len(req.Rows) == 0 {
+		return nil, nil, fmt.Errorf("the file is empty")
+	}
+	header := req.Rows[0]
+	columns, err := mapColumns(header, req.Mapping)
+	if err != nil {
+		return nil, nil, err
+	}
+	extra := map[string]int{}
+	for _, name := range req.ExtraFields {
+		i, ok := findHeader(header, name)
+		if !ok {
+			return nil, nil, fmt.Errorf("extra field %q is not in the header", name)
+		}
+		extra[name] = i
+	}
+	cell := func(record []string, i int, ok bool) string {
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		return cell(record, i, ok)
+	}
+
+	students, rejected := []Student{}, []Unallocated{}
+	seen := map[string]int{}
+	for i, record := range req.Rows[1:] {
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		s := Student{
+			Row:        i + 2,
+			RollNumber: strings.ToUpper(field(record, FieldRollNo)),
+			Name:       field(record, FieldName),
+			SOE:        field(record, FieldSOE),
+			Programme:  field(record, FieldProgramme),
+			Branch:     field(record, FieldBranch),
+			FatherName: field(record, FieldFatherName),
+			MotherName: field(record, FieldMotherName),
+		}
+		reject := func(format string, args ...any) {
+			rejected = append(rejected, Unallocated{Student: s, Reason: fmt.Sprintf(format, args...)})
+		}
+		if len(extra) > 0 {
+			s.Extra = make(map[string]string, len(extra))
+			for name, i := range extra {
+				s.Extra[name] = cell(record, i, true)
+			}
+		}
+		if s.RollNumber == "" {
+			reject("missing roll number")
+			continue
+		}
+		if row, ok := seen[s.RollNumber]; ok {
+			reject("roll number already on row %d", row)
+			continue
+		}
+		seen[s.RollNumber] = s.Row
+		gender, err := importer.NormalizeGender(field(record, FieldGender))
+		if err != nil {
+			reject("%v", err)
+			continue
+		}
+		s.Gender = gender
+		if s.Year, s.Batch, err = readSeniority(field(record, FieldYear), field(record, FieldBatch)); err != nil {
+			reject("%v", err)
+			continue
+		}
+		if raw := field(record, FieldCGPI); raw != "" {
+			if s.CGPI, err = strconv.ParseFloat(raw, 64); err != nil {
+				reject("invalid CGPI %q", raw)
+				continue
+			}
+			s.CGPISource = "sheet"
+		}
+		// the result store is more current than a sheet typed up by hand
+		if lookup != nil {
+			if cgpi, ok := lookup(s.RollNumber); ok {
+				s.CGPI, s.CGPISource = cgpi, "store"
+			}
+		}
+		students = append(students, s)
+	}
+	return students, rejected, nil
+}
+
+// readSeniority reads the year of study and the batch of a row. A year
+// column holding a four digit year is taken as the batch, as sheets often
+// head the batch "Year".
+func readSeniority(rawYear, rawBatch string) (year, batch int, err error) {
+	if rawYear != "" {
+		n, err := strconv.Atoi(rawYear)
+		switch {
+		case err != nil:
+			return 0, 0, fmt.Errorf("invalid year %q", rawYear)
+		case n >= 1 && n <= MaxYearOfStudy:
+			year = n
+		case isBatchYear(n):
+			batch = n
+		default:
+			return 0, 0, fmt.Errorf("year %q is neither a year of study (1 to %d) nor a batch", rawYear, MaxYearOfStudy)
+		}
+	}
+	if rawBatch != "" {
+		n, err := strconv.Atoi(rawBatch)
+		if err != nil || !isBatchYear(n) {
+			return 0, 0, fmt.Errorf("invalid batch %q", rawBatch)
+		}
+		batch = n
+	}
+	return year, batch, nil
+}
+
+func isBatchYear(n int) bool {
+	return n >= 1000 && n <= 9999
+}
+
+// mapColumns finds the column index of every field. Explicit mappings name a
+// header or a 1-based column number; the roll number column is required.
+func mapColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	columns := map[string]int{}
+	for field, target := range mapping {
+		if _, ok := headerAliases[field]; !ok {
+			return nil, fmt.Errorf("unknown field %q in field mapping", field)
+		}
+		if n, err := strconv.Atoi(target); err == nil {
+			if n < 1 {
+				return nil, fmt.Errorf("column number for %s must be at least 1", field)
+			}
+			columns[field] = n - 1
+			continue
+		}
+		i, ok := findHeader(header, target)
+		if !ok {
+			return nil, fmt.Errorf("column %q mapped to %s is not in the header", target, field)
+		}
+		columns[field] = i
+	}
+	for field, aliases := range headerAliases {
+		if _, ok := columns[field]; ok {
+			continue
+		}
+		for _, alias := range aliases {
+			if i, ok := findHeader(header, alias); ok {
+				columns[field] = i
+				break
+			}
+		}
+	}
+	if _, ok := columns[FieldRollNo]; !ok {
+		return nil, fmt.Errorf("no roll number column found, map one with rollNo")
+	}
+	return columns, nil
+}
+
+func findHeader(header []string, name string) (int, bool) {
+	for i, h := range header {
+		if importer.Squash(h) == importer.Squash(name) {
+			return i, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,41 @@
+package analytics
+
+import "sync"
+
+// Cache memoises computed reports for as long as the data they were computed
+// from is unchanged. Callers pass the current version of their data source
+// (e.g. store.Memory.Version); a newer version drops every entry, and values
+// computed for an older version are returned but not kept.
+type Cache struct {
+	mu      sync.Mutex
+	version uint64
+	entries map[string]any
+}
+
+func NewCache() *Cache {
+	return &Cache{entries: map[string]any{}}
+}
+
+// Get returns the cached value for key at version, computing and storing it
+// on a miss. The second result reports whether it was a hit.
+func (c *Cache) Get(key string, version uint64, compute func() any) (any, bool) {
+	c.mu.Lock()
+	if version > c.version {
+		c.version = version
+		c.entries = map[string]any{}
+	}
+	if v, ok := c.entries[key]; ok {
+		c.mu.Unlock()
+		return v, true
+	}
+	c.mu.Unlock()
+
+	v := compute()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if version == c.version {
+		c.entries[key] = v
+	}
+	return v, false
+}
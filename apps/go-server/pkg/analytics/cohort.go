@@ -0,0 +1,242 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/kanakkholwal/go-server/types"
+)
+
+// Summary describes a distribution of grade point averages.
+type Summary struct {
+	Count       int                `json:"count"`
+	Mean        float64            `json:"mean"`
+	Median      float64            `json:"median"`
+	StdDev      float64            `json:"stdDev"`
+	Min         float64            `json:"min"`
+	Max         float64            `json:"max"`
+	Percentiles map[string]float64 `json:"percentiles"`
+}
+
+// Bucket is one bar of a histogram over [From, To).
+type Bucket struct {
+	From  float64 `json:"from"`
+	To    float64 `json:"to"`
+	Count int     `json:"count"`
+}
+
+// SemesterStats is the SGPI distribution of one semester, with the mean CGPI
+// after it as the trend line.
+type SemesterStats struct {
+	Semester  string   `json:"semester"`
+	SGPI      Summary  `json:"sgpi"`
+	Histogram []Bucket `json:"histogram"`
+	MeanCGPI  float64  `json:"meanCgpi"`
+}
+
+type Topper struct {
+	Rank       int     `json:"rank"`
+	RollNumber string  `json:"rollNo"`
+	Name       string  `json:"name"`
+	Branch     string  `json:"branch"`
+	CGPI       float64 `json:"cgpi"`
+}
+
+type CutoffShare struct {
+	Cutoff float64 `json:"cutoff"`
+	Count  int     `json:"count"`
+	Share  float64 `json:"share"`
+}
+
+type CohortStats struct {
+	Students  int             `json:"students"`
+	CGPI      Summary         `json:"cgpi"`
+	Semesters []SemesterStats `json:"semesters"`
+	Toppers   []Topper        `json:"toppers"`
+	Cutoffs   []CutoffShare   `json:"cutoffs"`
+}
+
+type StatsOptions struct {
+	TopN        int
+	Cutoffs     []float64
+	BucketWidth float64
+}
+
+// DefaultStatsOptions are used for options left at their zero value.
+var DefaultStatsOptions = StatsOptions{
+	TopN:        10,
+	Cutoffs:     []float64{6, 7, 8, 9},
+	BucketWidth: 0.5,
+}
+
+var reportedPercentiles = []float64{10, 25, 50, 75, 90}
+
+// CohortStatistics summarises the CGPI of a cohort, the SGPI distribution of
+// every semester, the semester-over-semester trend and the toppers.
+func CohortStatistics(students []types.StudentHtmlParsed, opts StatsOptions) CohortStats {
+	if opts.TopN <= 0 {
+		opts.TopN = DefaultStatsOptions.TopN
+	}
+	if opts.Cutoffs == nil {
+		opts.Cutoffs = DefaultStatsOptions.Cutoffs
+	}
+	if opts.BucketWidth <= 0 {
+		opts.BucketWidth = DefaultStatsOptions.BucketWidth
+	}
+
+	stats := CohortStats{Students: len(students), Semesters: []SemesterStats{}, Toppers: []Topper{}, Cutoffs: []CutoffShare{}}
+	cgpis := make([]float64, 0, len(students))
+	sgpis := map[string][]float64{}
+	semCGPIs := map[string][]float64{}
+	for _, student := range students {
+		cgpis = append(cgpis, student.CGPI)
+		for _, sem := range student.SemesterResults {
+			sgpis[sem.SemesterNumber] = append(sgpis[sem.SemesterNumber], sem.SGPI)
+			semCGPIs[sem.SemesterNumber] = append(semCGPIs[sem.SemesterNumber], sem.CGPI)
+		}
+	}
+	stats.CGPI = Summarise(cgpis)
+
+	semesters := make([]string, 0, len(sgpis))
+	for semester := range sgpis {
+		semesters = append(semesters, semester)
+	}
+	sort.Slice(semesters, func(i, j int) bool { return semesterLess(semesters[i], semesters[j]) })
+	for _, semester := range semesters {
+		stats.Semesters = append(stats.Semesters, SemesterStats{
+			Semester:  semester,
+			SGPI:      Summarise(sgpis[semester]),
+			Histogram: Histogram(sgpis[semester], opts.BucketWidth),
+			MeanCGPI:  round(mean(semCGPIs[semester]), 2),
+		})
+	}
+
+	ranked := append([]types.StudentHtmlParsed(nil), students...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].CGPI != ranked[j].CGPI {
+			return ranked[i].CGPI > ranked[j].CGPI
+		}
+		return ranked[i].RollNumber < ranked[j].RollNumber
+	})
+	for i, student := range ranked {
+		if i >= opts.TopN {
+			break
+		}
+		// students with the same CGPI share a rank
+		rank := i + 1
+		if i > 0 && student.CGPI == ranked[i-1].CGPI {
+			rank = stats.Toppers[i-1].Rank
+		}
+		stats.Toppers = append(stats.Toppers, Topper{
+			Rank:       rank,
+			RollNumber: student.RollNumber,
+			Name:       student.Name,
+			Branch:     student.Branch,
+			CGPI:       student.CGPI,
+		})
+	}
+
+	for _, cutoff := range opts.Cutoffs {
+		share := CutoffShare{Cutoff: cutoff}
+		for _, cgpi := range cgpis {
+			if cgpi >= cutoff {
+				share.Count++
+			}
+		}
+		if len(cgpis) > 0 {
+			share.Share = round(float64(share.Count)/float64(len(cgpis)), 4)
+		}
+		stats.Cutoffs = append(stats.Cutoffs, share)
+	}
+	return stats
+}
+
+// Summarise computes the summary statistics of values.
+func Summarise(values []float64) Summary {
+	summary := Summary{Count: len(values), Percentiles: map[string]float64{}}
+	if len(values) == 0 {
+		return summary
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	m := mean(sorted)
+	var variance float64
+	for _, v := range sorted {
+		variance += (v - m) * (v - m)
+	}
+	summary.Mean = round(m, 2)
+	summary.StdDev = round(math.Sqrt(variance/float64(len(sorted))), 2)
+	summary.Min = sorted[0]
+	summary.Max = sorted[len(sorted)-1]
+	summary.Median = round(Percentile(sorted, 50), 2)
+	for _, p := range reportedPercentiles {
+		summary.Percentiles["p"+strconv.Itoa(int(p))] = round(Percentile(sorted, p), 2)
+	}
+	return summary
+}
+
+// Percentile returns the p-th percentile (0-100) of sorted values, linearly
+// interpolating between the closest ranks.
+func Percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	pos := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	if lo == hi {
+		return sorted[lo]
+	}
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(pos-float64(lo))
+}
+
+// Histogram buckets values in [0, 10] into bins of the given width; the last
+// bin also holds values equal to 10.
+func Histogram(values []float64, width float64) []Bucket {
+	n := int(math.Ceil(10 / width))
+	buckets := make([]Bucket, n)
+	for i := range buckets {
+		buckets[i].From = round(float64(i)*width, 2)
+		buckets[i].To = round(math.Min(float64(i+1)*width, 10), 2)
+	}
+	for _, v := range values {
+		i := int(v / width)
+		if i >= n {
+			i = n - 1
+		}
+		if i < 0 {
+			i = 0
+		}
+		buckets[i].Count++
+	}
+	return buckets
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// semesterLess orders numeric semester labels numerically and anything else
+// after them, alphabetically.
+func semesterLess(a, b string) bool {
+	x, errA := strconv.Atoi(a)
+	y, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return x < y
+	case errA == nil:
+		return true
+	case errB == nil:
+		return false
+	default:
+		return a < b
+	}
+}
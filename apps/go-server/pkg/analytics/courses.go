@@ -0,0 +1,341 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/kanakkholwal/go-server/pkg/catalog"
+	"github.com/kanakkholwal/go-server/pkg/grades"
+	"github.com/kanakkholwal/go-server/types"
+)
+
+// Dimensions a course distribution can be grouped by.
+const (
+	GroupBatch     = "batch"
+	GroupBranch    = "branch"
+	GroupSemester  = "semester"
+	GroupProgramme = "programme"
+)
+
+// electiveShare is the share of a class below which a course is treated as an
+// elective rather than a core course of that class.
+const electiveShare = 0.8
+
+// GradeStats summarises the grades of one group of students at a course,
+// each counted once with their latest attempt. Groups smaller than the
+// minimum cohort size are suppressed: only their size is reported.
+type GradeStats struct {
+	Batch          int            `json:"batch,omitempty"`
+	Branch         string         `json:"branch,omitempty"`
+	Semester       string         `json:"semester,omitempty"`
+	Programme      string         `json:"programme,omitempty"`
+	Students       int            `json:"students"`
+	Suppressed     bool           `json:"suppressed,omitempty"`
+	Histogram      map[string]int `json:"histogram,omitempty"`
+	MeanGradePoint float64        `json:"meanGradePoint,omitempty"`
+	PassRate       float64        `json:"passRate,omitempty"`
+}
+
+// CourseQuery selects the students of one course. Students are expected to be
+// filtered by batch/branch beforehand; Semester narrows further.
+type CourseQuery struct {
+	Code      string
+	Semester  string
+	GroupBy   []string
+	MinCohort int
+}
+
+type CourseReport struct {
+	Code      string       `json:"code"`
+	Name      string       `json:"name"`
+	MinCohort int          `json:"minCohort"`
+	Overall   GradeStats   `json:"overall"`
+	Groups    []GradeStats `json:"groups"`
+}
+
+// attempt is one graded occurrence of a course on a student's result.
+type attempt struct {
+	batch     int
+	branch    string
+	semester  string
+	programme string
+	grade     string
+	point     float64
+	pass      bool
+}
+
+type accumulator struct {
+	stats  GradeStats
+	points float64
+	graded int
+	passed int
+}
+
+func (a *accumulator) add(at attempt) {
+	a.stats.Students++
+	if a.stats.Histogram == nil {
+		a.stats.Histogram = map[string]int{}
+	}
+	a.stats.Histogram[at.grade]++
+	a.points += at.point
+	a.graded++
+	if at.pass {
+		a.passed++
+	}
+}
+
+func (a *accumulator) finish(minCohort int) GradeStats {
+	stats := a.stats
+	if stats.Students < minCohort {
+		stats.Suppressed = true
+		stats.Histogram = nil
+		return stats
+	}
+	if a.graded > 0 {
+		stats.MeanGradePoint = round(a.points/float64(a.graded), 2)
+		stats.PassRate = round(float64(a.passed)/float64(a.graded), 4)
+	}
+	return stats
+}
+
+// collectAttempts returns the latest graded attempt of every student at the
+// course with the given catalogue key, so a student who repeated the course
+// counts once, with the grade they ended with. Audit grades carry no grade
+// point and are skipped.
+func collectAttempts(students []types.StudentHtmlParsed, key, semester string) (attempts []attempt, code, name string) {
+	codes := map[string]int{}
+	names := map[string]int{}
+	for _, student := range students {
+		var latest *attempt
+		var latestCode, latestName string
+		for _, sem := range student.SemesterResults {
+			if semester != "" && sem.SemesterNumber != semester {
+				continue
+			}
+			scale := grades.ForScheme(sem.Scheme)
+			for _, subject := range sem.SubjectResults {
+				if catalog.Key(subject.SubjectCode) != key {
+					continue
+				}
+				grade, known := scale.Lookup(subject.Grade)
+				if known && grade.Audit {
+					continue
+				}
+				point, _ := scale.GradePoint(subject)
+				pass := subject.Points > 0
+				if known {
+					pass = grade.Pass
+				}
+				latestCode, latestName = strings.ToUpper(strings.TrimSpace(subject.SubjectCode)), subject.SubjectName
+				latest = &attempt{
+					batch:     student.Batch,
+					branch:    student.Branch,
+					semester:  sem.SemesterNumber,
+					programme: student.Programme,
+					grade:     strings.ToUpper(strings.TrimSpace(subject.Grade)),
+					point:     point,
+					pass:      pass,
+				}
+			}
+		}
+		if latest != nil {
+			codes[latestCode]++
+			names[latestName]++
+			attempts = append(attempts, *latest)
+		}
+	}
+	return attempts, mostFrequent(codes), mostFrequent(names)
+}
+
+// CourseDistribution computes the grade histogram, mean grade point and pass
+// rate of a course overall and per group.
+func CourseDistribution(students []types.StudentHtmlParsed, q CourseQuery) CourseReport {
+	key := catalog.Key(q.Code)
+	attempts, code, name := collectAttempts(students, key, q.Semester)
+	if code == "" {
+		code = q.Code
+	}
+	report := CourseReport{Code: code, Name: name, MinCohort: q.MinCohort, Groups: []GradeStats{}}
+
+	overall := &accumulator{}
+	type groupKey struct {
+		batch                       int
+		branch, semester, programme string
+	}
+	groups := map[groupKey]*accumulator{}
+	for _, at := range attempts {
+		overall.add(at)
+		if len(q.GroupBy) == 0 {
+			continue
+		}
+		id := groupKey{}
+		for _, dim := range q.GroupBy {
+			switch dim {
+			case GroupBatch:
+				id.batch = at.batch
+			case GroupBranch:
+				id.branch = at.branch
+			case GroupSemester:
+				id.semester = at.semester
+			case GroupProgramme:
+				id.programme = at.programme
+			}
+		}
+		acc, ok := groups[id]
+		if !ok {
+			acc = &accumulator{stats: GradeStats{Batch: id.batch, Branch: id.branch, Semester: id.semester, Programme: id.programme}}
+			groups[id] = acc
+		}
+		acc.add(at)
+	}
+	report.Overall = overall.finish(q.MinCohort)
+	for _, acc := range groups {
+		report.Groups = append(report.Groups, acc.finish(q.MinCohort))
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		a, b := report.Groups[i], report.Groups[j]
+		if a.Batch != b.Batch {
+			return a.Batch < b.Batch
+		}
+		if a.Programme != b.Programme {
+			return a.Programme < b.Programme
+		}
+		if a.Branch != b.Branch {
+			return a.Branch < b.Branch
+		}
+		return a.Semester < b.Semester
+	})
+	return report
+}
+
+// CourseDifficulty is the overall standing of one course, used to rank
+// courses from toughest to easiest.
+type CourseDifficulty struct {
+	Code           string  `json:"code"`
+	Name           string  `json:"name"`
+	Students       int     `json:"students"`
+	MeanGradePoint float64 `json:"meanGradePoint"`
+	PassRate       float64 `json:"passRate"`
+	Elective       bool    `json:"elective"`
+}
+
+// RankCourses orders the courses taken by the students from the lowest mean
+// grade point up, ties broken by pass rate, counting each student's latest
+// attempt. Courses taken by fewer than minCohort students are left out. A
+// course is an elective when, in the classes (batch, branch, semester) that
+// took it, fewer than 80% of the students did.
+func RankCourses(students []types.StudentHtmlParsed, minCohort int, electivesOnly bool) []CourseDifficulty {
+	type class struct {
+		batch    int
+		branch   string
+		semester string
+	}
+	type course struct {
+		acc     accumulator
+		codes   map[string]int
+		names   map[string]int
+		classes map[class]int
+	}
+	classSize := map[class]int{}
+	courses := map[string]*course{}
+
+	type taken struct {
+		attempt
+		cl         class
+		code, name string
+	}
+	for _, student := range students {
+		// the latest attempt of each course, so repeaters count once
+		latest := map[string]taken{}
+		order := []string{}
+		for _, sem := range student.SemesterResults {
+			cl := class{student.Batch, student.Branch, sem.SemesterNumber}
+			classSize[cl]++
+			scale := grades.ForScheme(sem.Scheme)
+			for _, subject := range sem.SubjectResults {
+				key := catalog.Key(subject.SubjectCode)
+				grade, known := scale.Lookup(subject.Grade)
+				if key == "" || (known && grade.Audit) {
+					continue
+				}
+				point, _ := scale.GradePoint(subject)
+				pass := subject.Points > 0
+				if known {
+					pass = grade.Pass
+				}
+				if _, ok := latest[key]; !ok {
+					order = append(order, key)
+				}
+				latest[key] = taken{
+					attempt: attempt{grade: strings.ToUpper(subject.Grade), point: point, pass: pass},
+					cl:      cl,
+					code:    strings.ToUpper(strings.TrimSpace(subject.SubjectCode)),
+					name:    subject.SubjectName,
+				}
+			}
+		}
+		for _, key := range order {
+			t := latest[key]
+			c, ok := courses[key]
+			if !ok {
+				c = &course{codes: map[string]int{}, names: map[string]int{}, classes: map[class]int{}}
+				courses[key] = c
+			}
+			c.acc.add(t.attempt)
+			c.codes[t.code]++
+			c.names[t.name]++
+			c.classes[t.cl]++
+		}
+	}
+
+	out := []CourseDifficulty{}
+	for _, c := range courses {
+		stats := c.acc.finish(minCohort)
+		if stats.Suppressed {
+			continue
+		}
+		takers, offered := 0, 0
+		for cl, n := range c.classes {
+			takers += n
+			offered += classSize[cl]
+		}
+		elective := offered > 0 && float64(takers)/float64(offered) < electiveShare
+		if electivesOnly && !elective {
+			continue
+		}
+		out = append(out, CourseDifficulty{
+			Code:           mostFrequent(c.codes),
+			Name:           mostFrequent(c.names),
+			Students:       stats.Students,
+			MeanGradePoint: stats.MeanGradePoint,
+			PassRate:       stats.PassRate,
+			Elective:       elective,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].MeanGradePoint != out[j].MeanGradePoint {
+			return out[i].MeanGradePoint < out[j].MeanGradePoint
+		}
+		if out[i].PassRate != out[j].PassRate {
+			return out[i].PassRate < out[j].PassRate
+		}
+		return out[i].Code < out[j].Code
+	})
+	return out
+}
+
+func mostFrequent(counts map[string]int) string {
+	best, bestCount := "", -1
+	for v, count := range counts {
+		if count > bestCount || (count == bestCount && v < best) {
+			best, bestCount = v, count
+		}
+	}
+	return best
+}
+
+func round(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(v*p) / p
+}
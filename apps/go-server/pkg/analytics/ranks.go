@@ -0,0 +1,70 @@
+package analytics
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/kanakkholwal/go-server/types"
+)
+
+// Ranks ranks every student by CGPI within their class, branch, batch and the
+// whole college, the scopes of StudentStanding, in one pass over the cohort.
+// A rank is one more than the number of peers with a higher CGPI. The result
+// is ordered by CGPI, highest first, ties by roll number.
+func Ranks(students []types.StudentHtmlParsed) []types.StudentResultWithRanks {
+	ranked := make([]types.StudentResultWithRanks, len(students))
+	for i, student := range students {
+		ranked[i] = types.StudentResultWithRanks{
+			RollNumber:  student.RollNumber,
+			Name:        student.Name,
+			FathersName: student.FathersName,
+			CGPI:        student.CGPI,
+			Branch:      student.Branch,
+			Batch:       strconv.Itoa(student.Batch),
+			Programme:   student.Programme,
+		}
+	}
+	for _, sc := range scopes {
+		// students of one group share the first member's index as group id
+		groups := map[int][]float64{}
+		groupOf := make([]int, len(students))
+		for i := range students {
+			groupOf[i] = i
+			for leader := range groups {
+				if sc.sameGroup(&students[i], &students[leader]) {
+					groupOf[i] = leader
+					break
+				}
+			}
+			groups[groupOf[i]] = append(groups[groupOf[i]], students[i].CGPI)
+		}
+		for _, cgpis := range groups {
+			sort.Sort(sort.Reverse(sort.Float64Slice(cgpis)))
+		}
+		for i := range students {
+			cgpis := groups[groupOf[i]]
+			// rank matches position: 1 + the number of clearly higher values
+			higher := sort.Search(len(cgpis), func(j int) bool { return cgpis[j] <= students[i].CGPI+1e-9 })
+			var sp types.ScopePositions
+			*sc.positions(&sp) = &types.Position{Rank: int64(higher + 1)}
+			rank := &ranked[i].Rank
+			switch {
+			case sp.Class != nil:
+				rank.ClassRank = sp.Class.Rank
+			case sp.Branch != nil:
+				rank.BranchRank = sp.Branch.Rank
+			case sp.Batch != nil:
+				rank.YearRank = sp.Batch.Rank
+			case sp.College != nil:
+				rank.CollegeRank = sp.College.Rank
+			}
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].CGPI != ranked[j].CGPI {
+			return ranked[i].CGPI > ranked[j].CGPI
+		}
+		return ranked[i].RollNumber < ranked[j].RollNumber
+	})
+	return ranked
+}
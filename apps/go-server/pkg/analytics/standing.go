@@ -0,0 +1,144 @@
+package analytics
+
+import (
+	"math"
+	"strings"
+
+	"github.com/kanakkholwal/go-server/types"
+)
+
+type scope struct {
+	positions func(sp *types.ScopePositions) **types.Position
+	sameGroup func(a, b *types.StudentHtmlParsed) bool
+}
+
+var scopes = []scope{
+	{
+		positions: func(sp *types.ScopePositions) **types.Position { return &sp.Class },
+		sameGroup: func(a, b *types.StudentHtmlParsed) bool {
+			return a.Batch == b.Batch && a.Branch == b.Branch && a.Programme == b.Programme
+		},
+	},
+	{
+		positions: func(sp *types.ScopePositions) **types.Position { return &sp.Branch },
+		sameGroup: func(a, b *types.StudentHtmlParsed) bool { return a.Batch == b.Batch && a.Branch == b.Branch },
+	},
+	{
+		positions: func(sp *types.ScopePositions) **types.Position { return &sp.Batch },
+		sameGroup: func(a, b *types.StudentHtmlParsed) bool { return a.Batch == b.Batch },
+	},
+	{
+		positions: func(sp *types.ScopePositions) **types.Position { return &sp.College },
+		sameGroup: func(a, b *types.StudentHtmlParsed) bool { return true },
+	},
+}
+
+// StudentStanding positions a student's CGPI, every semester's SGPI and the
+// semester-over-semester SGPI change among peers in each scope. A stored copy
+// of the student among peers is replaced by student itself.
+func StudentStanding(student *types.StudentHtmlParsed, peers []types.StudentHtmlParsed) types.Standing {
+	everyone := make([]*types.StudentHtmlParsed, 0, len(peers)+1)
+	for i := range peers {
+		if !strings.EqualFold(peers[i].RollNumber, student.RollNumber) {
+			everyone = append(everyone, &peers[i])
+		}
+	}
+	everyone = append(everyone, student)
+
+	standing := types.Standing{Semesters: []types.SemesterStanding{}}
+	for _, sem := range student.SemesterResults {
+		standing.Semesters = append(standing.Semesters, types.SemesterStanding{Semester: sem.SemesterNumber})
+	}
+
+	for _, sc := range scopes {
+		group := make([]*types.StudentHtmlParsed, 0, len(everyone))
+		for _, peer := range everyone {
+			if sc.sameGroup(student, peer) {
+				group = append(group, peer)
+			}
+		}
+
+		cgpis := make([]float64, 0, len(group))
+		for _, peer := range group {
+			cgpis = append(cgpis, peer.CGPI)
+		}
+		*sc.positions(&standing.CGPI) = position(student.CGPI, cgpis)
+
+		for i, sem := range student.SemesterResults {
+			sgpis := []float64{}
+			for _, peer := range group {
+				if v, ok := semesterSGPI(peer, sem.SemesterNumber); ok {
+					sgpis = append(sgpis, v)
+				}
+			}
+			*sc.positions(&standing.Semesters[i].SGPI) = position(sem.SGPI, sgpis)
+
+			if i == 0 {
+				continue
+			}
+			prevLabel := student.SemesterResults[i-1].SemesterNumber
+			change := round(sem.SGPI-student.SemesterResults[i-1].SGPI, 2)
+			changes := []float64{}
+			for _, peer := range group {
+				cur, ok1 := semesterSGPI(peer, sem.SemesterNumber)
+				prev, ok2 := semesterSGPI(peer, prevLabel)
+				if ok1 && ok2 {
+					changes = append(changes, cur-prev)
+				}
+			}
+			if standing.Semesters[i].Improvement == nil {
+				standing.Semesters[i].SGPIChange = &change
+				standing.Semesters[i].Improvement = &types.ScopePositions{}
+			}
+			*sc.positions(standing.Semesters[i].Improvement) = position(change, changes)
+		}
+	}
+
+	standing.Rank = types.Rank{
+		ClassRank:   standing.CGPI.Class.Rank,
+		BranchRank:  standing.CGPI.Branch.Rank,
+		YearRank:    standing.CGPI.Batch.Rank,
+		CollegeRank: standing.CGPI.College.Rank,
+	}
+	return standing
+}
+
+func semesterSGPI(student *types.StudentHtmlParsed, semester string) (float64, bool) {
+	for _, sem := range student.SemesterResults {
+		if sem.SemesterNumber == semester {
+			return sem.SGPI, true
+		}
+	}
+	return 0, false
+}
+
+// position computes rank (1 + number of higher values), mid-rank percentile
+// and z-score of value within values.
+func position(value float64, values []float64) *types.Position {
+	pos := &types.Position{Rank: 1, Peers: len(values)}
+	if len(values) == 0 {
+		return pos
+	}
+	const eps = 1e-9
+	var below, equal int
+	for _, v := range values {
+		switch {
+		case v > value+eps:
+			pos.Rank++
+		case v < value-eps:
+			below++
+		default:
+			equal++
+		}
+	}
+	pos.Percentile = round((float64(below)+float64(equal)/2)/float64(len(values))*100, 2)
+	m := mean(values)
+	var variance float64
+	for _, v := range values {
+		variance += (v - m) * (v - m)
+	}
+	if sd := math.Sqrt(variance / float64(len(values))); sd > 0 {
+		pos.ZScore = round((value-m)/sd, 2)
+	}
+	return pos
+}
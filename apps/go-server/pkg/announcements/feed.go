@@ -0,0 +1,230 @@
+// Package announcements tracks the notices, tenders and events posted on
+// nith.ac.in, remembering when each item was first seen so new ones can be
+// served as a feed.
+package announcements
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kanakkholwal/go-server/pkg/refresher"
+	"github.com/kanakkholwal/go-server/pkg/scrape"
+)
+
+// documentName is the store document the feed is persisted as.
+const documentName = "announcements"
+
+// maxItems bounds how many announcements are kept; the oldest go first.
+const maxItems = 500
+
+// Fetcher scrapes the announcements of one page.
+type Fetcher func(ctx context.Context, spec scrape.ListSpec) ([]scrape.Announcement, error)
+
+// Item is an announcement with the time the feed first saw it.
+type Item struct {
+	scrape.Announcement
+	FirstSeen time.Time `json:"firstSeen"`
+}
+
+// Source is the refresh state of one page.
+type Source struct {
+	Kind  string `json:"kind"`
+	URL   string `json:"url"`
+	Count int    `json:"count"`
+	// RefreshedAt is the last refresh that read the page.
+	RefreshedAt *time.Time `json:"refreshedAt,omitempty"`
+	// Error is why the latest refresh failed for the page.
+	Error string `json:"error,omitempty"`
+}
+
+// Snapshot is the feed as of its latest refresh, newest items first.
+type Snapshot struct {
+	RefreshedAt *time.Time `json:"refreshedAt,omitempty"`
+	Items       []Item     `json:"items"`
+	Sources     []Source   `json:"sources"`
+}
+
+// Feed is the announcements of every page, refreshed from the pages and
+// persisted after every refresh.
+type Feed struct {
+	mu        sync.RWMutex
+	snap      Snapshot
+	specs     []scrape.ListSpec
+	fetch     Fetcher
+	refresher refresher.Refresher
+}
+
+// New loads the feed saved in persist. specs default to
+// scrape.AnnouncementPages and fetch to scrape.GetAnnouncements. An
+// unreadable saved feed is logged and the feed starts empty.
+func New(persist refresher.Persister, specs []scrape.ListSpec, fetch Fetcher) *Feed {
+	if specs == nil {
+		specs = scrape.AnnouncementPages
+	}
+	if fetch == nil {
+		fetch = scrape.GetAnnouncements
+	}
+	f := &Feed{specs: specs, fetch: fetch, refresher: refresher.Refresher{Name: "announcements", Document: documentName, Persist: persist}}
+	var snap Snapshot
+	if !f.refresher.Load(&snap) {
+		snap = Snapshot{}
+	}
+	f.set(snap)
+	return f
+}
+
+func (f *Feed) set(snap Snapshot) {
+	if snap.Items == nil {
+		snap.Items = []Item{}
+	}
+	if snap.Sources == nil {
+		snap.Sources = []Source{}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.snap = snap
+}
+
+// Snapshot returns the feed as of its latest refresh.
+func (f *Feed) Snapshot() Snapshot {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.snap
+}
+
+// Kinds lists the kinds of the scraped pages.
+func (f *Feed) Kinds() []string {
+	kinds := make([]string, len(f.specs))
+	for i, spec := range f.specs {
+		kinds[i] = spec.Name
+	}
+	return kinds
+}
+
+// Filter selects feed items. Zero fields select everything.
+type Filter struct {
+	Kind  string
+	Since time.Time
+	Query string
+	Limit int
+}
+
+// Items returns the items first seen after Since, newest first.
+func (f *Feed) Items(filter Filter) []Item {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	query := strings.ToLower(filter.Query)
+	out := []Item{}
+	for _, item := range f.snap.Items {
+		if filter.Limit > 0 && len(out) == filter.Limit {
+			break
+		}
+		if filter.Kind != "" && item.Kind != filter.Kind {
+			continue
+		}
+		if !filter.Since.IsZero() && !item.FirstSeen.After(filter.Since) {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(item.Title+" "+item.Summary), query) {
+			continue
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+// RefreshReport is the outcome of one refresh.
+type RefreshReport struct {
+	RefreshedAt time.Time         `json:"refreshedAt"`
+	Count       int               `json:"count"`
+	New         []Item            `json:"new"`
+	Failed      map[string]string `json:"failed,omitempty"`
+}
+
+// Refresh scrapes every page again and adds the items not seen before.
+// Items that drop off a page stay in the feed until maxItems pushes them out.
+// Pages that fail are listed in the report; nothing changes when all fail.
+// A refresh that is already running makes it return a
+// refresher.ErrRefreshing error.
+func (f *Feed) Refresh(ctx context.Context) (report *RefreshReport, err error) {
+	err = f.refresher.Run(func() error {
+		report, err = f.refresh(ctx)
+		return err
+	})
+	return report, err
+}
+
+func (f *Feed) refresh(ctx context.Context) (*RefreshReport, error) {
+	now := time.Now().UTC()
+	prev := f.Snapshot()
+	known := make(map[string]bool, len(prev.Items))
+	for _, item := range prev.Items {
+		known[item.ID] = true
+	}
+	prevSources := map[string]Source{}
+	for _, source := range prev.Sources {
+		prevSources[source.Kind] = source
+	}
+
+	fresh := []Item{}
+	sources := make([]Source, 0, len(f.specs))
+	failed := map[string]string{}
+	var lastErr error
+	for _, spec := range f.specs {
+		source := Source{Kind: spec.Name, URL: spec.URL, RefreshedAt: &now}
+		found, err := f.fetch(ctx, spec)
+		if err != nil {
+			source.RefreshedAt = prevSources[spec.Name].RefreshedAt
+			source.Error = err.Error()
+			failed[spec.Name], lastErr = err.Error(), err
+		}
+		for _, a := range found {
+			if !known[a.ID] {
+				known[a.ID] = true
+				fresh = append(fresh, Item{Announcement: a, FirstSeen: now})
+			}
+		}
+		sources = append(sources, source)
+	}
+	if len(failed) == len(f.specs) && len(f.specs) > 0 {
+		return nil, lastErr
+	}
+
+	// new items go first, each page in page order, which is newest first
+	items := append(fresh, prev.Items...)
+	if len(items) > maxItems {
+		items = items[:maxItems]
+	}
+	counts := map[string]int{}
+	for _, item := range items {
+		counts[item.Kind]++
+	}
+	for i := range sources {
+		sources[i].Count = counts[sources[i].Kind]
+	}
+	next := Snapshot{RefreshedAt: &now, Items: items, Sources: sources}
+	if err := f.refresher.Save(next); err != nil {
+		return nil, err
+	}
+	f.set(next)
+	report := &RefreshReport{RefreshedAt: now, Count: len(items), New: fresh}
+	if len(failed) > 0 {
+		report.Failed = failed
+	}
+	return report, nil
+}
+
+// Schedule refreshes the feed every interval until ctx is done, each refresh
+// bounded by timeout; see refresher.Refresher.Schedule.
+func (f *Feed) Schedule(ctx context.Context, interval, timeout time.Duration) {
+	f.refresher.Schedule(ctx, interval, timeout, f.Snapshot().RefreshedAt, func(ctx context.Context) (string, error) {
+		report, err := f.Refresh(ctx)
+		if err != nil || len(report.Failed) == 0 {
+			return "", err
+		}
+		return fmt.Sprintf("%d new, could not read the %s pages", len(report.New), refresher.Keys(report.Failed)), nil
+	})
+}
@@ -0,0 +1,178 @@
+package announcements
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+// Channel describes the feed in RSS and Atom output. Self is the URL the
+// feed is served at.
+type Channel struct {
+	Title       string
+	Link        string
+	Self        string
+	Description string
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Atom    string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Self          atomLink  `xml:"atom:link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link,omitempty"`
+	Description string  `xml:"description,omitempty"`
+	Category    string  `xml:"category"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title     string       `xml:"title"`
+	ID        string       `xml:"id"`
+	Updated   string       `xml:"updated"`
+	Published string       `xml:"published,omitempty"`
+	Links     []atomLink   `xml:"link,omitempty"`
+	Category  atomCategory `xml:"category"`
+	Summary   string       `xml:"summary,omitempty"`
+	Author    atomAuthor   `xml:"author"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+// published is the date printed on the item, or when it was first seen.
+func (item Item) published() time.Time {
+	if item.Date != nil {
+		return *item.Date
+	}
+	return item.FirstSeen
+}
+
+func (item Item) entryID() string {
+	return "urn:nith-announcement:" + item.ID
+}
+
+// WriteRSS writes the items as an RSS 2.0 feed.
+func WriteRSS(w io.Writer, channel Channel, items []Item, updated time.Time) error {
+	feed := rssFeed{Version: "2.0", Atom: "http://www.w3.org/2005/Atom", Channel: rssChannel{
+		Title:       channel.Title,
+		Link:        channel.Link,
+		Self:        atomLink{Href: channel.Self, Rel: "self", Type: "application/rss+xml"},
+		Description: channel.Description,
+		Items:       make([]rssItem, len(items)),
+	}}
+	if !updated.IsZero() {
+		feed.Channel.LastBuildDate = updated.Format(time.RFC1123Z)
+	}
+	for i, item := range items {
+		feed.Channel.Items[i] = rssItem{
+			Title:       item.Title,
+			Link:        item.URL,
+			Description: item.Summary,
+			Category:    item.Kind,
+			GUID:        rssGUID{Value: item.entryID()},
+			PubDate:     item.published().Format(time.RFC1123Z),
+		}
+	}
+	return writeXML(w, feed)
+}
+
+// WriteAtom writes the items as an Atom feed. Atom requires an updated
+// time: without one, before the first refresh, the newest item's is used,
+// or the current time when there are no items.
+func WriteAtom(w io.Writer, channel Channel, items []Item, updated time.Time) error {
+	if updated.IsZero() {
+		updated = time.Now().UTC()
+		if newest := latestSeen(items); !newest.IsZero() {
+			updated = newest
+		}
+	}
+	feed := atomFeed{
+		Title:   channel.Title,
+		ID:      channel.Self,
+		Updated: updated.Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: channel.Self, Rel: "self", Type: "application/atom+xml"},
+			{Href: channel.Link, Rel: "alternate", Type: "text/html"},
+		},
+		Entries: make([]atomEntry, len(items)),
+	}
+	for i, item := range items {
+		entry := atomEntry{
+			Title:     item.Title,
+			ID:        item.entryID(),
+			Updated:   item.FirstSeen.Format(time.RFC3339),
+			Published: item.published().Format(time.RFC3339),
+			Category:  atomCategory{Term: item.Kind},
+			Summary:   item.Summary,
+			Author:    atomAuthor{Name: channel.Title},
+		}
+		if item.URL != "" {
+			entry.Links = []atomLink{{Href: item.URL, Rel: "alternate"}}
+		}
+		feed.Entries[i] = entry
+	}
+	return writeXML(w, feed)
+}
+
+func writeXML(w io.Writer, v any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// latestSeen is the latest FirstSeen of the items, or zero for none.
+func latestSeen(items []Item) time.Time {
+	var latest time.Time
+	for _, item := range items {
+		if item.FirstSeen.After(latest) {
+			latest = item.FirstSeen
+		}
+	}
+	return latest
+}
@@ -0,0 +1,189 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kanakkholwal/go-server/pkg/scrape"
+	"github.com/kanakkholwal/go-server/pkg/store"
+	"github.com/kanakkholwal/go-server/types"
+)
+
+// FromStudent converts an internal result to its version 1 form.
+func FromStudent(student types.StudentHtmlParsed) Student {
+	out := Student{
+		RollNumber: student.RollNumber,
+		Name:       student.Name,
+		FatherName: student.FathersName,
+		Batch:      student.Batch,
+		Branch:     student.Branch,
+		Programme:  student.Programme,
+		CGPI:       student.CGPI,
+		Semesters:  make([]Semester, 0, len(student.SemesterResults)),
+		Abnormal:   student.Abnormal,
+		Warnings:   student.Warnings,
+	}
+	for _, sem := range student.SemesterResults {
+		semester := Semester{
+			Semester:  sem.SemesterNumber,
+			Phase:     sem.Phase,
+			Scheme:    sem.Scheme,
+			SGPI:      sem.SGPI,
+			CGPI:      sem.CGPI,
+			SGPITotal: sem.SGPITotal,
+			CGPITotal: sem.CGPITotal,
+			Courses:   make([]Course, 0, len(sem.SubjectResults)),
+		}
+		for _, subject := range sem.SubjectResults {
+			semester.Courses = append(semester.Courses, Course{
+				Name:   subject.SubjectName,
+				Code:   subject.SubjectCode,
+				Credit: subject.Credit,
+				Grade:  subject.Grade,
+				Points: subject.Points,
+				CGPI:   subject.CGPI,
+			})
+		}
+		out.Semesters = append(out.Semesters, semester)
+	}
+	for _, phase := range student.Phases {
+		out.Phases = append(out.Phases, Phase(phase))
+	}
+	for _, flag := range student.QualityFlags {
+		out.QualityFlags = append(out.QualityFlags, QualityFlag(flag))
+	}
+	return out
+}
+
+// ToStudent converts a version 1 result back to the internal type. Derived
+// fields are left empty; run it through scrape.Enrich to recompute them.
+func ToStudent(student Student) types.StudentHtmlParsed {
+	out := types.StudentHtmlParsed{
+		RollNumber:      student.RollNumber,
+		Name:            student.Name,
+		FathersName:     student.FatherName,
+		Batch:           student.Batch,
+		Branch:          student.Branch,
+		Programme:       student.Programme,
+		CGPI:            student.CGPI,
+		SemesterResults: make([]types.SemesterResult, 0, len(student.Semesters)),
+	}
+	for _, sem := range student.Semesters {
+		semester := types.SemesterResult{
+			SemesterNumber: sem.Semester,
+			Phase:          sem.Phase,
+			Scheme:         sem.Scheme,
+			SGPI:           sem.SGPI,
+			CGPI:           sem.CGPI,
+			SGPITotal:      sem.SGPITotal,
+			CGPITotal:      sem.CGPITotal,
+		}
+		for _, course := range sem.Courses {
+			semester.SubjectResults = append(semester.SubjectResults, types.SubjectResult{
+				SubjectName: course.Name,
+				SubjectCode: course.Code,
+				Credit:      course.Credit,
+				Grade:       course.Grade,
+				Points:      course.Points,
+				CGPI:        course.CGPI,
+			})
+		}
+		out.SemesterResults = append(out.SemesterResults, semester)
+	}
+	return out
+}
+
+func FromScrapeResults(results []scrape.ScrapeResult) []ScrapeResult {
+	out := make([]ScrapeResult, 0, len(results))
+	for _, res := range results {
+		entry := ScrapeResult{RollNumber: res.RollNumber, Error: res.Error}
+		if res.Data != nil {
+			student := FromStudent(*res.Data)
+			entry.Data = &student
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+func FromPage(page store.Page) Page {
+	out := Page{Total: page.Total, Page: page.Page, Limit: page.Limit, Results: make([]Student, 0, len(page.Results))}
+	for _, student := range page.Results {
+		out.Results = append(out.Results, FromStudent(student))
+	}
+	return out
+}
+
+// UnmarshalJSON accepts the current "semester" string as well as the integer
+// "semesterNo" of older dumps.
+func (s *Semester) UnmarshalJSON(data []byte) error {
+	type plain Semester
+	var raw struct {
+		plain
+		Semester   json.RawMessage `json:"semester"`
+		SemesterNo json.RawMessage `json:"semesterNo"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*s = Semester(raw.plain)
+	number := raw.Semester
+	if len(number) == 0 || string(number) == "null" {
+		number = raw.SemesterNo
+	}
+	if len(number) == 0 || string(number) == "null" {
+		return nil
+	}
+	label, err := looseString(number)
+	if err != nil {
+		return fmt.Errorf("semester: %w", err)
+	}
+	s.Semester = label
+	return nil
+}
+
+// UnmarshalJSON accepts the batch as a number or, as ranked results used to
+// send it, as a string.
+func (s *Student) UnmarshalJSON(data []byte) error {
+	type plain Student
+	var raw struct {
+		plain
+		Batch json.RawMessage `json:"batch"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*s = Student(raw.plain)
+	if len(raw.Batch) == 0 || string(raw.Batch) == "null" {
+		return nil
+	}
+	batch, err := looseString(raw.Batch)
+	if err != nil {
+		return fmt.Errorf("batch: %w", err)
+	}
+	if batch == "" {
+		return nil
+	}
+	if s.Batch, err = strconv.Atoi(batch); err != nil {
+		return fmt.Errorf("batch: %q is not a year", batch)
+	}
+	return nil
+}
+
+// looseString reads a JSON string or number as a string.
+func looseString(raw json.RawMessage) (string, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) > 0 && raw[0] == '"' {
+		var s string
+		err := json.Unmarshal(raw, &s)
+		return strings.TrimSpace(s), err
+	}
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err != nil {
+		return "", fmt.Errorf("expected a string or a number")
+	}
+	return n.String(), nil
+}
@@ -0,0 +1,8 @@
+package v1
+
+import _ "embed"
+
+// Schema is the JSON Schema of the version 1 payloads.
+//
+//go:embed schema.json
+var Schema []byte
@@ -0,0 +1,90 @@
+// Package v1 defines the version 1 wire format of the result API. The types
+// carry the fields the unversioned /api routes returned when version 1 was
+// cut, including phases, the abnormal flag, quality flags and warnings, and
+// are frozen: internal types may change, these may not. schema.json
+// describes them and is served from the binary; the OpenAPI document of the
+// /api/v1 routes is generated from the route table.
+package v1
+
+// Version is sent in the API-Version header of every /api/v1 response.
+const Version = "1"
+
+type Student struct {
+	RollNumber   string        `json:"rollNo"`
+	Name         string        `json:"name"`
+	FatherName   string        `json:"fatherName"`
+	Batch        int           `json:"batch"`
+	Branch       string        `json:"branch"`
+	Programme    string        `json:"programme"`
+	CGPI         float64       `json:"cgpi"`
+	Semesters    []Semester    `json:"semesters"`
+	Phases       []Phase       `json:"phases,omitempty"`
+	Abnormal     bool          `json:"abnormal,omitempty"`
+	QualityFlags []QualityFlag `json:"qualityFlags,omitempty"`
+	Warnings     []string      `json:"warnings,omitempty"`
+}
+
+// Semester is one semester of a result. Semester is a string ("1", "2", ...)
+// numbered across programme phases; version 1 dumps written before phases
+// existed used an integer "semesterNo", which UnmarshalJSON still accepts.
+type Semester struct {
+	Semester  string   `json:"semester"`
+	Phase     string   `json:"phase,omitempty"`
+	Scheme    string   `json:"scheme,omitempty"`
+	SGPI      float64  `json:"sgpi"`
+	CGPI      float64  `json:"cgpi"`
+	SGPITotal int64    `json:"sgpi_total"`
+	CGPITotal int64    `json:"cgpi_total"`
+	Courses   []Course `json:"courses"`
+}
+
+// Course is one course of a semester. CGPI holds the grade point of the
+// course's grade, not a cumulative figure; the name is kept for version 1
+// consumers.
+type Course struct {
+	Name   string  `json:"name"`
+	Code   string  `json:"code"`
+	Credit int64   `json:"credit"`
+	Grade  string  `json:"grade"`
+	Points int64   `json:"points"`
+	CGPI   float64 `json:"cgpi"`
+}
+
+type Phase struct {
+	Phase     string  `json:"phase"`
+	Scheme    string  `json:"scheme"`
+	CGPI      float64 `json:"cgpi"`
+	Credits   int64   `json:"credits"`
+	Semesters int     `json:"semesters"`
+}
+
+type QualityFlag struct {
+	Code     string `json:"code"`
+	Severity string `json:"severity"`
+	Semester string `json:"semester,omitempty"`
+	Course   string `json:"course,omitempty"`
+	Message  string `json:"message"`
+}
+
+// ScrapeResult is one entry of a bulk scrape: Data on success, Error otherwise.
+type ScrapeResult struct {
+	RollNumber string   `json:"rollNumber"`
+	Data       *Student `json:"data,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+type BulkRequest struct {
+	RollNumbers []string `json:"rollNumbers"`
+}
+
+// Page is one page of stored results.
+type Page struct {
+	Total   int       `json:"total"`
+	Page    int       `json:"page"`
+	Limit   int       `json:"limit"`
+	Results []Student `json:"results"`
+}
+
+type Error struct {
+	Error string `json:"error"`
+}
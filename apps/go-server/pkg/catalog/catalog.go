@@ -0,0 +1,375 @@
+package catalog
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kanakkholwal/go-server/types"
+)
+
+// NameVariant is one spelling of a course name and how often it was seen.
+type NameVariant struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// CreditRecord is a credit value a course carried in one scheme.
+type CreditRecord struct {
+	Credit     int64  `json:"credit"`
+	Scheme     string `json:"scheme"`
+	FirstBatch int    `json:"firstBatch"`
+	LastBatch  int    `json:"lastBatch"`
+	Students   int    `json:"students"`
+}
+
+// BatchUsage describes who took a course in one batch.
+type BatchUsage struct {
+	Batch     int      `json:"batch"`
+	Students  int      `json:"students"`
+	Credits   []int64  `json:"credits"`
+	Branches  []string `json:"branches"`
+	Semesters []string `json:"semesters"`
+}
+
+// Course is everything the scraped results say about one course code.
+type Course struct {
+	Code           string         `json:"code"`
+	Name           string         `json:"name"`
+	NameVariants   []NameVariant  `json:"nameVariants"`
+	CreditHistory  []CreditRecord `json:"creditHistory"`
+	Semesters      []string       `json:"semesters"`
+	Branches       []string       `json:"branches"`
+	Programmes     []string       `json:"programmes"`
+	FirstSeenBatch int            `json:"firstSeenBatch"`
+	LastSeenBatch  int            `json:"lastSeenBatch"`
+	// Students counts distinct roll numbers; Attempts counts every time the
+	// course shows up, so a repeated course adds to Attempts only.
+	Students int          `json:"students"`
+	Attempts int          `json:"attempts"`
+	Batches  []BatchUsage `json:"batches,omitempty"`
+}
+
+// CreditChanged reports whether the course carried more than one credit value.
+func (c *Course) CreditChanged() bool {
+	if len(c.CreditHistory) == 0 {
+		return false
+	}
+	for _, record := range c.CreditHistory[1:] {
+		if record.Credit != c.CreditHistory[0].Credit {
+			return true
+		}
+	}
+	return false
+}
+
+// occurrence is one course on one student's result.
+type occurrence struct {
+	code      string
+	name      string
+	credit    int64
+	semester  string
+	scheme    string
+	batch     int
+	branch    string
+	programme string
+}
+
+// Catalog builds a course registry from scraped students. It keeps the course
+// rows of the latest scrape of every roll number and aggregates them lazily,
+// so re-scraping a student replaces rather than double counts their courses.
+type Catalog struct {
+	mu      sync.RWMutex
+	byRoll  map[string][]occurrence
+	courses map[string]*Course
+	dirty   bool
+}
+
+func New() *Catalog {
+	return &Catalog{byRoll: map[string][]occurrence{}, courses: map[string]*Course{}}
+}
+
+// Key normalises a course code for lookups: "cs 101", "CS-101" and "CS101"
+// share the key "CS101".
+func Key(code string) string {
+	r := strings.NewReplacer(" ", "", "-", "", "_", "")
+	return strings.ToUpper(r.Replace(strings.TrimSpace(code)))
+}
+
+// Observe records (or replaces) the courses of a scraped student.
+func (c *Catalog) Observe(student *types.StudentHtmlParsed) {
+	if student == nil || student.RollNumber == "" {
+		return
+	}
+	rows := []occurrence{}
+	for _, sem := range student.SemesterResults {
+		for _, subject := range sem.SubjectResults {
+			if Key(subject.SubjectCode) == "" {
+				continue
+			}
+			rows = append(rows, occurrence{
+				code:      strings.ToUpper(strings.TrimSpace(subject.SubjectCode)),
+				name:      strings.TrimSpace(subject.SubjectName),
+				credit:    subject.Credit,
+				semester:  sem.SemesterNumber,
+				scheme:    sem.Scheme,
+				batch:     student.Batch,
+				branch:    student.Branch,
+				programme: student.Programme,
+			})
+		}
+	}
+	c.mu.Lock()
+	c.byRoll[strings.ToUpper(student.RollNumber)] = rows
+	c.dirty = true
+	c.mu.Unlock()
+}
+
+// Len returns the number of distinct course codes.
+func (c *Catalog) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rebuild()
+	return len(c.courses)
+}
+
+// Get returns a course with its per batch usage.
+func (c *Catalog) Get(code string) (*Course, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rebuild()
+	course, ok := c.courses[Key(code)]
+	return course, ok
+}
+
+// Search returns courses whose code or any name variant contains query
+// (case-insensitive), optionally limited to a branch and batch, sorted by code.
+// Per batch usage is left out of the listing.
+func (c *Catalog) Search(query, branch string, batch int) []Course {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rebuild()
+	query = strings.ToLower(strings.TrimSpace(query))
+	out := []Course{}
+	for _, course := range c.courses {
+		if query != "" && !matchesQuery(course, query) {
+			continue
+		}
+		if branch != "" && !containsFold(course.Branches, branch) {
+			continue
+		}
+		if batch != 0 && !hasBatch(course, batch) {
+			continue
+		}
+		listed := *course
+		listed.Batches = nil
+		out = append(out, listed)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Code < out[j].Code })
+	return out
+}
+
+// CreditChanges returns the courses whose credits differ between schemes or
+// batches, sorted by code.
+func (c *Catalog) CreditChanges() []Course {
+	out := []Course{}
+	for _, course := range c.Search("", "", 0) {
+		if course.CreditChanged() {
+			out = append(out, course)
+		}
+	}
+	return out
+}
+
+func (c *Catalog) rebuild() {
+	if !c.dirty {
+		return
+	}
+	type creditKey struct {
+		credit int64
+		scheme string
+	}
+	type builder struct {
+		course    *Course
+		names     map[string]int
+		codes     map[string]int
+		credits   map[creditKey]*CreditRecord
+		semesters map[string]bool
+		branches  map[string]bool
+		progs     map[string]bool
+		batches   map[int]*BatchUsage
+	}
+	builders := map[string]*builder{}
+	for _, rows := range c.byRoll {
+		// what this student was already counted in, so repeated courses
+		// count the student once
+		counted := map[any]bool{}
+		once := func(key any) int {
+			if counted[key] {
+				return 0
+			}
+			counted[key] = true
+			return 1
+		}
+		for _, row := range rows {
+			key := Key(row.code)
+			b, ok := builders[key]
+			if !ok {
+				b = &builder{
+					course:    &Course{FirstSeenBatch: row.batch, LastSeenBatch: row.batch},
+					names:     map[string]int{},
+					codes:     map[string]int{},
+					credits:   map[creditKey]*CreditRecord{},
+					semesters: map[string]bool{},
+					branches:  map[string]bool{},
+					progs:     map[string]bool{},
+					batches:   map[int]*BatchUsage{},
+				}
+				builders[key] = b
+			}
+			b.course.Students += once(key)
+			b.course.Attempts++
+			b.names[row.name]++
+			b.codes[row.code]++
+			b.semesters[row.semester] = true
+			b.branches[row.branch] = true
+			b.progs[row.programme] = true
+			if row.batch < b.course.FirstSeenBatch {
+				b.course.FirstSeenBatch = row.batch
+			}
+			if row.batch > b.course.LastSeenBatch {
+				b.course.LastSeenBatch = row.batch
+			}
+
+			ck := creditKey{row.credit, row.scheme}
+			record, ok := b.credits[ck]
+			if !ok {
+				record = &CreditRecord{Credit: row.credit, Scheme: row.scheme, FirstBatch: row.batch, LastBatch: row.batch}
+				b.credits[ck] = record
+			}
+			record.Students += once(struct {
+				key string
+				creditKey
+			}{key, ck})
+			record.FirstBatch = min(record.FirstBatch, row.batch)
+			record.LastBatch = max(record.LastBatch, row.batch)
+
+			usage, ok := b.batches[row.batch]
+			if !ok {
+				usage = &BatchUsage{Batch: row.batch}
+				b.batches[row.batch] = usage
+			}
+			usage.Students += once(struct {
+				key   string
+				batch int
+			}{key, row.batch})
+			usage.Credits = appendUniqueInt(usage.Credits, row.credit)
+			usage.Branches = appendUnique(usage.Branches, row.branch)
+			usage.Semesters = appendUnique(usage.Semesters, row.semester)
+		}
+	}
+
+	c.courses = make(map[string]*Course, len(builders))
+	for key, b := range builders {
+		course := b.course
+		course.Code = mostCommon(b.codes)
+		course.Name = mostCommon(b.names)
+		for name, count := range b.names {
+			course.NameVariants = append(course.NameVariants, NameVariant{Name: name, Count: count})
+		}
+		sort.Slice(course.NameVariants, func(i, j int) bool {
+			if course.NameVariants[i].Count != course.NameVariants[j].Count {
+				return course.NameVariants[i].Count > course.NameVariants[j].Count
+			}
+			return course.NameVariants[i].Name < course.NameVariants[j].Name
+		})
+		for _, record := range b.credits {
+			course.CreditHistory = append(course.CreditHistory, *record)
+		}
+		sort.Slice(course.CreditHistory, func(i, j int) bool {
+			a, b := course.CreditHistory[i], course.CreditHistory[j]
+			if a.FirstBatch != b.FirstBatch {
+				return a.FirstBatch < b.FirstBatch
+			}
+			return a.Scheme < b.Scheme
+		})
+		course.Semesters = sortedKeys(b.semesters)
+		course.Branches = sortedKeys(b.branches)
+		course.Programmes = sortedKeys(b.progs)
+		for _, usage := range b.batches {
+			sort.Strings(usage.Branches)
+			sort.Strings(usage.Semesters)
+			course.Batches = append(course.Batches, *usage)
+		}
+		sort.Slice(course.Batches, func(i, j int) bool { return course.Batches[i].Batch < course.Batches[j].Batch })
+		c.courses[key] = course
+	}
+	c.dirty = false
+}
+
+func matchesQuery(course *Course, query string) bool {
+	if strings.Contains(strings.ToLower(course.Code), query) || strings.Contains(Key(course.Code), Key(query)) {
+		return true
+	}
+	for _, variant := range course.NameVariants {
+		if strings.Contains(strings.ToLower(variant.Name), query) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasBatch(course *Course, batch int) bool {
+	for _, usage := range course.Batches {
+		if usage.Batch == batch {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(list []string, v string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func mostCommon(counts map[string]int) string {
+	best, bestCount := "", -1
+	for v, count := range counts {
+		if count > bestCount || (count == bestCount && v < best) {
+			best, bestCount = v, count
+		}
+	}
+	return best
+}
+
+func sortedKeys(set map[string]bool) []string {
+	out := make([]string, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func appendUnique(list []string, v string) []string {
+	for _, item := range list {
+		if item == v {
+			return list
+		}
+	}
+	return append(list, v)
+}
+
+func appendUniqueInt(list []int64, v int64) []int64 {
+	for _, item := range list {
+		if item == v {
+			return list
+		}
+	}
+	return append(list, v)
+}
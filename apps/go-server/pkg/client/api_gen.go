@@ -0,0 +1,1755 @@
+// Code generated by cmd/genclient from the OpenAPI document; DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type AbnormalEntry struct {
+	Abnormal     bool          `json:"abnormal"`
+	Batch        int           `json:"batch"`
+	Branch       string        `json:"branch"`
+	Name         string        `json:"name"`
+	QualityFlags []QualityFlag `json:"qualityFlags"`
+	RollNo       string        `json:"rollNo"`
+}
+
+type AbnormalList struct {
+	Count   int             `json:"count"`
+	Job     JobsSummary     `json:"job"`
+	Records []AbnormalEntry `json:"records"`
+}
+
+type AcademicStatus struct {
+	ActiveBacklogs     int             `json:"activeBacklogs"`
+	Backlogs           []BacklogCourse `json:"backlogs"`
+	ClearedBacklogs    int             `json:"clearedBacklogs"`
+	OutstandingCredits int64           `json:"outstandingCredits"`
+	Status             string          `json:"status"`
+}
+
+type AllotmentLogEntry struct {
+	Message string `json:"message"`
+	RollNo  string `json:"rollNo,omitempty"`
+	Room    string `json:"room,omitempty"`
+}
+
+type AllotmentResult struct {
+	CreatedAt   time.Time                 `json:"createdAt"`
+	DryRun      bool                      `json:"dryRun"`
+	ExtraFields []string                  `json:"extraFields,omitempty"`
+	ID          string                    `json:"id,omitempty"`
+	Log         []AllotmentLogEntry       `json:"log"`
+	Rooms       []AllotmentRoomAllocation `json:"rooms"`
+	Rules       AllotmentRules            `json:"rules"`
+	Summary     AllotmentSummary          `json:"summary"`
+	Unallocated []AllotmentUnallocated    `json:"unallocated"`
+}
+
+type AllotmentRoomAllocation struct {
+	Block    string          `json:"block,omitempty"`
+	Capacity int             `json:"capacity"`
+	Free     int             `json:"free"`
+	ID       string          `json:"id"`
+	Students []AllotmentSeat `json:"students"`
+}
+
+type AllotmentRules struct {
+	Gender      string   `json:"gender,omitempty"`
+	GroupBy     string   `json:"groupBy,omitempty"`
+	Priority    []string `json:"priority,omitempty"`
+	SoePriority string   `json:"soePriority,omitempty"`
+}
+
+type AllotmentSeat struct {
+	Batch      int               `json:"batch,omitempty"`
+	Branch     string            `json:"branch,omitempty"`
+	CGPI       float64           `json:"cgpi"`
+	CGPISource string            `json:"cgpiSource,omitempty"`
+	Extra      map[string]string `json:"extra,omitempty"`
+	FatherName string            `json:"fatherName,omitempty"`
+	Gender     string            `json:"gender"`
+	MotherName string            `json:"motherName,omitempty"`
+	Name       string            `json:"name"`
+	Programme  string            `json:"programme,omitempty"`
+	Rank       int               `json:"rank"`
+	Reason     string            `json:"reason"`
+	RollNo     string            `json:"rollNo"`
+	Row        int               `json:"row"`
+	Seat       int               `json:"seat"`
+	Soe        string            `json:"soe,omitempty"`
+	Year       int               `json:"year,omitempty"`
+}
+
+type AllotmentSummary struct {
+	Allocated   int `json:"allocated"`
+	Eligible    int `json:"eligible"`
+	FreeSeats   int `json:"freeSeats"`
+	Rooms       int `json:"rooms"`
+	Rows        int `json:"rows"`
+	Seats       int `json:"seats"`
+	Unallocated int `json:"unallocated"`
+}
+
+type AllotmentUnallocated struct {
+	Batch      int               `json:"batch,omitempty"`
+	Branch     string            `json:"branch,omitempty"`
+	CGPI       float64           `json:"cgpi"`
+	CGPISource string            `json:"cgpiSource,omitempty"`
+	Extra      map[string]string `json:"extra,omitempty"`
+	FatherName string            `json:"fatherName,omitempty"`
+	Gender     string            `json:"gender"`
+	MotherName string            `json:"motherName,omitempty"`
+	Name       string            `json:"name"`
+	Programme  string            `json:"programme,omitempty"`
+	Reason     string            `json:"reason"`
+	RollNo     string            `json:"rollNo"`
+	Row        int               `json:"row"`
+	Soe        string            `json:"soe,omitempty"`
+	Year       int               `json:"year,omitempty"`
+}
+
+type AnalyticsBucket struct {
+	Count int     `json:"count"`
+	From  float64 `json:"from"`
+	To    float64 `json:"to"`
+}
+
+type AnalyticsCohortStats struct {
+	CGPI      AnalyticsSummary         `json:"cgpi"`
+	Cutoffs   []AnalyticsCutoffShare   `json:"cutoffs"`
+	Semesters []AnalyticsSemesterStats `json:"semesters"`
+	Students  int                      `json:"students"`
+	Toppers   []AnalyticsTopper        `json:"toppers"`
+}
+
+type AnalyticsCourseDifficulty struct {
+	Code           string  `json:"code"`
+	Elective       bool    `json:"elective"`
+	MeanGradePoint float64 `json:"meanGradePoint"`
+	Name           string  `json:"name"`
+	PassRate       float64 `json:"passRate"`
+	Students       int     `json:"students"`
+}
+
+type AnalyticsCourseReport struct {
+	Code      string                `json:"code"`
+	Groups    []AnalyticsGradeStats `json:"groups"`
+	MinCohort int                   `json:"minCohort"`
+	Name      string                `json:"name"`
+	Overall   AnalyticsGradeStats   `json:"overall"`
+}
+
+type AnalyticsCutoffShare struct {
+	Count  int     `json:"count"`
+	Cutoff float64 `json:"cutoff"`
+	Share  float64 `json:"share"`
+}
+
+type AnalyticsGradeStats struct {
+	Batch          int            `json:"batch,omitempty"`
+	Branch         string         `json:"branch,omitempty"`
+	Histogram      map[string]int `json:"histogram,omitempty"`
+	MeanGradePoint float64        `json:"meanGradePoint,omitempty"`
+	PassRate       float64        `json:"passRate,omitempty"`
+	Programme      string         `json:"programme,omitempty"`
+	Semester       string         `json:"semester,omitempty"`
+	Students       int            `json:"students"`
+	Suppressed     bool           `json:"suppressed,omitempty"`
+}
+
+type AnalyticsSemesterStats struct {
+	Histogram []AnalyticsBucket `json:"histogram"`
+	MeanCGPI  float64           `json:"meanCgpi"`
+	Semester  string            `json:"semester"`
+	SGPI      AnalyticsSummary  `json:"sgpi"`
+}
+
+type AnalyticsSummary struct {
+	Count       int                `json:"count"`
+	Max         float64            `json:"max"`
+	Mean        float64            `json:"mean"`
+	Median      float64            `json:"median"`
+	Min         float64            `json:"min"`
+	Percentiles map[string]float64 `json:"percentiles"`
+	StdDev      float64            `json:"stdDev"`
+}
+
+type AnalyticsTopper struct {
+	Branch string  `json:"branch"`
+	CGPI   float64 `json:"cgpi"`
+	Name   string  `json:"name"`
+	Rank   int     `json:"rank"`
+	RollNo string  `json:"rollNo"`
+}
+
+type AnnouncementList struct {
+	Count       int                 `json:"count"`
+	Items       []AnnouncementsItem `json:"items"`
+	RefreshedAt *time.Time          `json:"refreshedAt,omitempty"`
+}
+
+type AnnouncementStatus struct {
+	Count       int                   `json:"count"`
+	RefreshedAt *time.Time            `json:"refreshedAt,omitempty"`
+	Sources     []AnnouncementsSource `json:"sources"`
+}
+
+type AnnouncementsItem struct {
+	Date      *time.Time `json:"date,omitempty"`
+	FirstSeen time.Time  `json:"firstSeen"`
+	ID        string     `json:"id"`
+	Kind      string     `json:"kind"`
+	Summary   string     `json:"summary,omitempty"`
+	Title     string     `json:"title"`
+	URL       string     `json:"url,omitempty"`
+}
+
+type AnnouncementsRefreshReport struct {
+	Count       int                 `json:"count"`
+	Failed      map[string]string   `json:"failed,omitempty"`
+	New         []AnnouncementsItem `json:"new"`
+	RefreshedAt time.Time           `json:"refreshedAt"`
+}
+
+type AnnouncementsSource struct {
+	Count       int        `json:"count"`
+	Error       string     `json:"error,omitempty"`
+	Kind        string     `json:"kind"`
+	RefreshedAt *time.Time `json:"refreshedAt,omitempty"`
+	URL         string     `json:"url"`
+}
+
+type BacklogCourse struct {
+	Cleared      bool     `json:"cleared"`
+	ClearedGrade string   `json:"clearedGrade,omitempty"`
+	ClearedIn    string   `json:"clearedIn,omitempty"`
+	Code         string   `json:"code"`
+	Credit       int64    `json:"credit"`
+	FailGrades   []string `json:"failGrades"`
+	FailedIn     []string `json:"failedIn"`
+	Name         string   `json:"name"`
+}
+
+type BacklogEntry struct {
+	AcademicStatus AcademicStatus `json:"academicStatus"`
+	Batch          int            `json:"batch"`
+	Branch         string         `json:"branch"`
+	Name           string         `json:"name"`
+	RollNo         string         `json:"rollNo"`
+}
+
+type BacklogList struct {
+	Count     int            `json:"count"`
+	Error     string         `json:"error,omitempty"`
+	Missing   []string       `json:"missing,omitempty"`
+	Requested int            `json:"requested"`
+	Students  []BacklogEntry `json:"students"`
+}
+
+type BatchCount struct {
+	Batch    int `json:"batch"`
+	Students int `json:"students"`
+}
+
+type BranchChangeEntry struct {
+	Batch      int             `json:"batch"`
+	BranchInfo BranchInference `json:"branchInfo"`
+	Name       string          `json:"name"`
+	Programme  string          `json:"programme"`
+	RollNo     string          `json:"rollNo"`
+}
+
+type BranchChangeList struct {
+	Count     int                 `json:"count"`
+	Error     string              `json:"error,omitempty"`
+	Missing   []string            `json:"missing,omitempty"`
+	Requested int                 `json:"requested"`
+	Students  []BranchChangeEntry `json:"students"`
+}
+
+type BranchInference struct {
+	Changed            bool           `json:"changed"`
+	Confidence         float64        `json:"confidence"`
+	CoursesConsidered  int            `json:"coursesConsidered"`
+	CurrentBranch      string         `json:"currentBranch"`
+	CurrentBranchCode  string         `json:"currentBranchCode"`
+	OriginalBranch     string         `json:"originalBranch"`
+	OriginalBranchCode string         `json:"originalBranchCode"`
+	PrefixCounts       map[string]int `json:"prefixCounts,omitempty"`
+}
+
+type BulkRequest struct {
+	RollNumbers []string `json:"rollNumbers"`
+}
+
+type CatalogBatchUsage struct {
+	Batch     int      `json:"batch"`
+	Branches  []string `json:"branches"`
+	Credits   []int64  `json:"credits"`
+	Semesters []string `json:"semesters"`
+	Students  int      `json:"students"`
+}
+
+type CatalogCourse struct {
+	Attempts       int                   `json:"attempts"`
+	Batches        []CatalogBatchUsage   `json:"batches,omitempty"`
+	Branches       []string              `json:"branches"`
+	Code           string                `json:"code"`
+	CreditHistory  []CatalogCreditRecord `json:"creditHistory"`
+	FirstSeenBatch int                   `json:"firstSeenBatch"`
+	LastSeenBatch  int                   `json:"lastSeenBatch"`
+	Name           string                `json:"name"`
+	NameVariants   []CatalogNameVariant  `json:"nameVariants"`
+	Programmes     []string              `json:"programmes"`
+	Semesters      []string              `json:"semesters"`
+	Students       int                   `json:"students"`
+}
+
+type CatalogCreditRecord struct {
+	Credit     int64  `json:"credit"`
+	FirstBatch int    `json:"firstBatch"`
+	LastBatch  int    `json:"lastBatch"`
+	Scheme     string `json:"scheme"`
+	Students   int    `json:"students"`
+}
+
+type CatalogNameVariant struct {
+	Count int    `json:"count"`
+	Name  string `json:"name"`
+}
+
+type CohortRollNumbers struct {
+	Count       int      `json:"count"`
+	RollNumbers []string `json:"rollNumbers"`
+}
+
+type CohortSelector struct {
+	Batches    []int    `json:"batches"`
+	Branches   []string `json:"branches,omitempty"`
+	Exclude    []string `json:"exclude,omitempty"`
+	Include    []string `json:"include,omitempty"`
+	Programmes []string `json:"programmes,omitempty"`
+	SerialFrom int      `json:"serialFrom,omitempty"`
+	SerialTo   int      `json:"serialTo,omitempty"`
+}
+
+type CourseBatches struct {
+	Batches []CatalogBatchUsage `json:"batches"`
+	Code    string              `json:"code"`
+	Name    string              `json:"name"`
+}
+
+type CourseList struct {
+	Count   int             `json:"count"`
+	Courses []CatalogCourse `json:"courses"`
+}
+
+type CourseRanking struct {
+	Courses  []AnalyticsCourseDifficulty `json:"courses"`
+	Students int                         `json:"students"`
+}
+
+type DepartmentInfo struct {
+	Branch       string          `json:"branch"`
+	Code         string          `json:"code"`
+	CoursePrefix string          `json:"course_prefix"`
+	Name         string          `json:"name"`
+	PageURL      string          `json:"page_url"`
+	Programmes   []string        `json:"programmes"`
+	RollKeys     []string        `json:"roll_keys"`
+	Short        string          `json:"short"`
+	Stats        DepartmentStats `json:"stats"`
+}
+
+type DepartmentList struct {
+	Count       int              `json:"count"`
+	Departments []DepartmentInfo `json:"departments"`
+}
+
+type DepartmentStats struct {
+	Faculty            int          `json:"faculty"`
+	FacultyRefreshedAt *time.Time   `json:"facultyRefreshedAt,omitempty"`
+	Students           int          `json:"students"`
+	StudentsPerBatch   []BatchCount `json:"studentsPerBatch"`
+}
+
+type FacultyDepartment struct {
+	Code        string     `json:"code"`
+	Count       int        `json:"count"`
+	Error       string     `json:"error,omitempty"`
+	RefreshedAt *time.Time `json:"refreshedAt,omitempty"`
+}
+
+type FacultyDiff struct {
+	Added   []ScrapeFaculty `json:"added"`
+	At      time.Time       `json:"at"`
+	Changed []ScrapeFaculty `json:"changed"`
+	Removed []ScrapeFaculty `json:"removed"`
+	Since   *time.Time      `json:"since,omitempty"`
+}
+
+type FacultyList struct {
+	Count       int             `json:"count"`
+	Department  string          `json:"department,omitempty"`
+	Faculty     []ScrapeFaculty `json:"faculty"`
+	RefreshedAt *time.Time      `json:"refreshedAt,omitempty"`
+}
+
+type FacultyMatch struct {
+	Faculty ScrapeFaculty `json:"faculty"`
+	Field   string        `json:"field"`
+	Score   float64       `json:"score"`
+}
+
+type FacultyRefreshReport struct {
+	Count       int               `json:"count"`
+	Diff        FacultyDiff       `json:"diff"`
+	Failed      map[string]string `json:"failed,omitempty"`
+	RefreshedAt time.Time         `json:"refreshedAt"`
+}
+
+type FacultySearch struct {
+	Count   int            `json:"count"`
+	Results []FacultyMatch `json:"results"`
+}
+
+type FacultyStatus struct {
+	Count       int                 `json:"count"`
+	Departments []FacultyDepartment `json:"departments"`
+	LastDiff    *FacultyDiff        `json:"lastDiff,omitempty"`
+	RefreshedAt *time.Time          `json:"refreshedAt,omitempty"`
+}
+
+type GradesGrade struct {
+	Audit             bool    `json:"audit"`
+	CountsTowardsCGPI bool    `json:"countsTowardsCgpi"`
+	Letter            string  `json:"letter"`
+	Pass              bool    `json:"pass"`
+	Point             float64 `json:"point"`
+}
+
+type GradesScale struct {
+	Grades  []GradesGrade `json:"grades"`
+	Name    string        `json:"name"`
+	Schemes []string      `json:"schemes"`
+}
+
+type HostelList struct {
+	FetchedAt time.Time           `json:"fetchedAt"`
+	Hostels   []ScrapeHostel      `json:"hostels"`
+	InCharges []ScrapeFunctionary `json:"inCharges"`
+	Skipped   []string            `json:"skipped,omitempty"`
+}
+
+type ImporterReport struct {
+	DryRun         bool               `json:"dryRun"`
+	Errors         []ImporterRowIssue `json:"errors"`
+	Flagged        []ImporterRowIssue `json:"flagged"`
+	Format         string             `json:"format"`
+	Freshers       int                `json:"freshers"`
+	Imported       int                `json:"imported"`
+	Rows           int                `json:"rows"`
+	SchemaVersions map[string]int     `json:"schemaVersions,omitempty"`
+	Skipped        int                `json:"skipped"`
+}
+
+type ImporterRowIssue struct {
+	Message      string        `json:"message"`
+	QualityFlags []QualityFlag `json:"qualityFlags,omitempty"`
+	RollNo       string        `json:"rollNo,omitempty"`
+	Row          int           `json:"row"`
+}
+
+type JobsSummary struct {
+	Abnormal   int            `json:"abnormal"`
+	Cohort     CohortSelector `json:"cohort"`
+	CreatedAt  time.Time      `json:"createdAt"`
+	Done       int            `json:"done"`
+	Failed     int            `json:"failed"`
+	FinishedAt *time.Time     `json:"finishedAt,omitempty"`
+	Found      int            `json:"found"`
+	ID         string         `json:"id"`
+	Status     string         `json:"status"`
+	Total      int            `json:"total"`
+}
+
+type OpenapiErrorBody struct {
+	Error string `json:"error"`
+}
+
+type PhaseSummary struct {
+	CGPI      float64 `json:"cgpi"`
+	Credits   int64   `json:"credits"`
+	Phase     string  `json:"phase"`
+	Scheme    string  `json:"scheme"`
+	Semesters int     `json:"semesters"`
+}
+
+type PlannerFutureSemester struct {
+	Courses []PlannerPlannedCourse `json:"courses,omitempty"`
+	Credits int64                  `json:"credits,omitempty"`
+	SGPI    float64                `json:"sgpi,omitempty"`
+}
+
+type PlannerGradeReplacement struct {
+	Code     string `json:"code"`
+	Grade    string `json:"grade"`
+	Semester string `json:"semester,omitempty"`
+}
+
+type PlannerPlan struct {
+	AfterReplacements *PlannerStanding           `json:"afterReplacements,omitempty"`
+	Current           PlannerStanding            `json:"current"`
+	Phase             string                     `json:"phase,omitempty"`
+	Projected         PlannerStanding            `json:"projected"`
+	Projection        []PlannerProjectedSemester `json:"projection"`
+	ReportedCGPI      float64                    `json:"reportedCgpi"`
+	RollNo            string                     `json:"rollNo"`
+	Target            *PlannerTargetAnswer       `json:"target,omitempty"`
+	Warnings          []string                   `json:"warnings,omitempty"`
+}
+
+type PlannerPlannedCourse struct {
+	Code   string `json:"code,omitempty"`
+	Credit int64  `json:"credit"`
+	Grade  string `json:"grade"`
+}
+
+type PlannerProjectedSemester struct {
+	CGPI    float64 `json:"cgpi"`
+	Credits int64   `json:"credits"`
+	Label   string  `json:"label"`
+	Points  float64 `json:"points"`
+	SGPI    float64 `json:"sgpi"`
+}
+
+type PlannerRequest struct {
+	FutureSemesters     []PlannerFutureSemester   `json:"futureSemesters,omitempty"`
+	NextSemesterCredits int64                     `json:"nextSemesterCredits,omitempty"`
+	Replacements        []PlannerGradeReplacement `json:"replacements,omitempty"`
+	Result              *StudentHtmlParsed        `json:"result,omitempty"`
+	RollNo              string                    `json:"rollNo,omitempty"`
+	TargetCGPI          float64                   `json:"targetCgpi,omitempty"`
+}
+
+type PlannerStanding struct {
+	CGPI    float64 `json:"cgpi"`
+	Credits int64   `json:"credits"`
+	Points  float64 `json:"points"`
+}
+
+type PlannerTargetAnswer struct {
+	Achievable   bool    `json:"achievable"`
+	Credits      int64   `json:"credits"`
+	MaxCGPI      float64 `json:"maxCgpi"`
+	RequiredSGPI float64 `json:"requiredSgpi"`
+	TargetCGPI   float64 `json:"targetCgpi"`
+}
+
+type Position struct {
+	Peers      int     `json:"peers"`
+	Percentile float64 `json:"percentile"`
+	Rank       int64   `json:"rank"`
+	ZScore     float64 `json:"zScore"`
+}
+
+type QualityFlag struct {
+	Code     string `json:"code"`
+	Course   string `json:"course,omitempty"`
+	Message  string `json:"message"`
+	Semester string `json:"semester,omitempty"`
+	Severity string `json:"severity"`
+}
+
+type Rank struct {
+	Branch  int64 `json:"branch"`
+	Class   int64 `json:"class"`
+	College int64 `json:"college"`
+	Year    int64 `json:"year"`
+}
+
+type ScopePositions struct {
+	Batch   *Position `json:"batch,omitempty"`
+	Branch  *Position `json:"branch,omitempty"`
+	Class   *Position `json:"class,omitempty"`
+	College *Position `json:"college,omitempty"`
+}
+
+type ScrapeFaculty struct {
+	Department    string   `json:"department"`
+	Designation   string   `json:"designation,omitempty"`
+	Email         string   `json:"email"`
+	Name          string   `json:"name"`
+	Phone         string   `json:"phone,omitempty"`
+	PhotoURL      string   `json:"photoUrl,omitempty"`
+	ProfileURL    string   `json:"profileUrl,omitempty"`
+	ResearchAreas []string `json:"researchAreas,omitempty"`
+}
+
+type ScrapeFunctionary struct {
+	Email       string   `json:"email,omitempty"`
+	Gender      string   `json:"gender,omitempty"`
+	Hostel      string   `json:"hostel,omitempty"`
+	Name        string   `json:"name"`
+	OtherEmails []string `json:"otherEmails,omitempty"`
+	Phone       string   `json:"phone,omitempty"`
+	Role        string   `json:"role"`
+}
+
+type ScrapeHostel struct {
+	Functionaries []ScrapeFunctionary `json:"functionaries"`
+	Gender        string              `json:"gender"`
+	Name          string              `json:"name"`
+	Slug          string              `json:"slug"`
+	Warden        *ScrapeFunctionary  `json:"warden,omitempty"`
+}
+
+type ScrapeResult struct {
+	Data       *StudentHtmlParsed `json:"data,omitempty"`
+	Error      string             `json:"error,omitempty"`
+	RollNumber string             `json:"rollNumber"`
+}
+
+type SemesterResult struct {
+	CGPI      float64         `json:"cgpi"`
+	CGPITotal int64           `json:"cgpi_total"`
+	Courses   []SubjectResult `json:"courses"`
+	Phase     string          `json:"phase,omitempty"`
+	Scheme    string          `json:"scheme,omitempty"`
+	Semester  string          `json:"semester"`
+	SGPI      float64         `json:"sgpi"`
+	SGPITotal int64           `json:"sgpi_total"`
+}
+
+type SemesterStanding struct {
+	Improvement *ScopePositions `json:"improvement,omitempty"`
+	Semester    string          `json:"semester"`
+	SGPI        ScopePositions  `json:"sgpi"`
+	SGPIChange  *float64        `json:"sgpiChange,omitempty"`
+}
+
+type Standing struct {
+	CGPI      ScopePositions     `json:"cgpi"`
+	Rank      Rank               `json:"rank"`
+	Semesters []SemesterStanding `json:"semesters"`
+}
+
+type StorePage struct {
+	Limit   int                 `json:"limit"`
+	Page    int                 `json:"page"`
+	Results []StudentHtmlParsed `json:"results"`
+	Total   int                 `json:"total"`
+}
+
+type StoreRevision struct {
+	ScrapedAt time.Time         `json:"scrapedAt"`
+	Student   StudentHtmlParsed `json:"student"`
+	Version   int               `json:"version"`
+}
+
+type StudentHtmlParsed struct {
+	Abnormal       bool             `json:"abnormal,omitempty"`
+	AcademicStatus *AcademicStatus  `json:"academicStatus,omitempty"`
+	Batch          int              `json:"batch"`
+	Branch         string           `json:"branch"`
+	BranchInfo     *BranchInference `json:"branchInfo,omitempty"`
+	CGPI           float64          `json:"cgpi"`
+	CGPIRule       string           `json:"cgpiRule,omitempty"`
+	FatherName     string           `json:"fatherName"`
+	Gender         string           `json:"gender,omitempty"`
+	Name           string           `json:"name"`
+	Phases         []PhaseSummary   `json:"phases,omitempty"`
+	Programme      string           `json:"programme"`
+	QualityFlags   []QualityFlag    `json:"qualityFlags,omitempty"`
+	RollNo         string           `json:"rollNo"`
+	Semesters      []SemesterResult `json:"semesters"`
+	Standing       *Standing        `json:"standing,omitempty"`
+	Warnings       []string         `json:"warnings,omitempty"`
+}
+
+type SubjectResult struct {
+	CGPI   float64 `json:"cgpi"`
+	Code   string  `json:"code"`
+	Credit int64   `json:"credit"`
+	Grade  string  `json:"grade"`
+	Name   string  `json:"name"`
+	Points int64   `json:"points"`
+}
+
+type V1BulkRequest struct {
+	RollNumbers []string `json:"rollNumbers"`
+}
+
+type V1Course struct {
+	CGPI   float64 `json:"cgpi"`
+	Code   string  `json:"code"`
+	Credit int64   `json:"credit"`
+	Grade  string  `json:"grade"`
+	Name   string  `json:"name"`
+	Points int64   `json:"points"`
+}
+
+type V1Page struct {
+	Limit   int         `json:"limit"`
+	Page    int         `json:"page"`
+	Results []V1Student `json:"results"`
+	Total   int         `json:"total"`
+}
+
+type V1Phase struct {
+	CGPI      float64 `json:"cgpi"`
+	Credits   int64   `json:"credits"`
+	Phase     string  `json:"phase"`
+	Scheme    string  `json:"scheme"`
+	Semesters int     `json:"semesters"`
+}
+
+type V1QualityFlag struct {
+	Code     string `json:"code"`
+	Course   string `json:"course,omitempty"`
+	Message  string `json:"message"`
+	Semester string `json:"semester,omitempty"`
+	Severity string `json:"severity"`
+}
+
+type V1ScrapeResult struct {
+	Data       *V1Student `json:"data,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	RollNumber string     `json:"rollNumber"`
+}
+
+type V1Semester struct {
+	CGPI      float64    `json:"cgpi"`
+	CGPITotal int64      `json:"cgpi_total"`
+	Courses   []V1Course `json:"courses"`
+	Phase     string     `json:"phase,omitempty"`
+	Scheme    string     `json:"scheme,omitempty"`
+	Semester  string     `json:"semester"`
+	SGPI      float64    `json:"sgpi"`
+	SGPITotal int64      `json:"sgpi_total"`
+}
+
+type V1Student struct {
+	Abnormal     bool            `json:"abnormal,omitempty"`
+	Batch        int             `json:"batch"`
+	Branch       string          `json:"branch"`
+	CGPI         float64         `json:"cgpi"`
+	FatherName   string          `json:"fatherName"`
+	Name         string          `json:"name"`
+	Phases       []V1Phase       `json:"phases,omitempty"`
+	Programme    string          `json:"programme"`
+	QualityFlags []V1QualityFlag `json:"qualityFlags,omitempty"`
+	RollNo       string          `json:"rollNo"`
+	Semesters    []V1Semester    `json:"semesters"`
+	Warnings     []string        `json:"warnings,omitempty"`
+}
+
+// CohortStatisticsParams are the query parameters of CohortStatistics.
+type CohortStatisticsParams struct {
+	Batch     string
+	Programme string
+	Branch    string
+	From      int64
+	To        int64
+	Include   string
+	Exclude   string
+	MinCohort int64
+	Top       int64
+	Cutoffs   string
+	Bucket    float64
+}
+
+// CohortStatistics calls GET /api/analytics/cohort: cGPI and SGPI statistics of stored results.
+func (c *Client) CohortStatistics(ctx context.Context, params CohortStatisticsParams) (AnalyticsCohortStats, error) {
+	var out AnalyticsCohortStats
+	query := url.Values{}
+	if params.Batch != "" {
+		query.Set("batch", params.Batch)
+	}
+	if params.Programme != "" {
+		query.Set("programme", params.Programme)
+	}
+	if params.Branch != "" {
+		query.Set("branch", params.Branch)
+	}
+	if params.From != 0 {
+		query.Set("from", strconv.FormatInt(params.From, 10))
+	}
+	if params.To != 0 {
+		query.Set("to", strconv.FormatInt(params.To, 10))
+	}
+	if params.Include != "" {
+		query.Set("include", params.Include)
+	}
+	if params.Exclude != "" {
+		query.Set("exclude", params.Exclude)
+	}
+	if params.MinCohort != 0 {
+		query.Set("minCohort", strconv.FormatInt(params.MinCohort, 10))
+	}
+	if params.Top != 0 {
+		query.Set("top", strconv.FormatInt(params.Top, 10))
+	}
+	if params.Cutoffs != "" {
+		query.Set("cutoffs", params.Cutoffs)
+	}
+	if params.Bucket != 0 {
+		query.Set("bucket", strconv.FormatFloat(params.Bucket, 'f', -1, 64))
+	}
+	err := c.call(ctx, "GET", "/api/analytics/cohort", query, nil, &out)
+	return out, err
+}
+
+// CourseDifficultyParams are the query parameters of CourseDifficulty.
+type CourseDifficultyParams struct {
+	Batch     string
+	Programme string
+	Branch    string
+	From      int64
+	To        int64
+	Include   string
+	Exclude   string
+	MinCohort int64
+	Electives bool
+	Limit     int64
+}
+
+// CourseDifficulty calls GET /api/analytics/courses/difficulty: courses from toughest to easiest.
+func (c *Client) CourseDifficulty(ctx context.Context, params CourseDifficultyParams) (CourseRanking, error) {
+	var out CourseRanking
+	query := url.Values{}
+	if params.Batch != "" {
+		query.Set("batch", params.Batch)
+	}
+	if params.Programme != "" {
+		query.Set("programme", params.Programme)
+	}
+	if params.Branch != "" {
+		query.Set("branch", params.Branch)
+	}
+	if params.From != 0 {
+		query.Set("from", strconv.FormatInt(params.From, 10))
+	}
+	if params.To != 0 {
+		query.Set("to", strconv.FormatInt(params.To, 10))
+	}
+	if params.Include != "" {
+		query.Set("include", params.Include)
+	}
+	if params.Exclude != "" {
+		query.Set("exclude", params.Exclude)
+	}
+	if params.MinCohort != 0 {
+		query.Set("minCohort", strconv.FormatInt(params.MinCohort, 10))
+	}
+	if params.Electives {
+		query.Set("electives", "true")
+	}
+	if params.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(params.Limit, 10))
+	}
+	err := c.call(ctx, "GET", "/api/analytics/courses/difficulty", query, nil, &out)
+	return out, err
+}
+
+// CourseDistributionParams are the query parameters of CourseDistribution.
+type CourseDistributionParams struct {
+	Batch     string
+	Programme string
+	Branch    string
+	From      int64
+	To        int64
+	Include   string
+	Exclude   string
+	MinCohort int64
+	Semester  string
+	GroupBy   string
+}
+
+// CourseDistribution calls GET /api/analytics/courses/{code}: grade distribution of one course.
+func (c *Client) CourseDistribution(ctx context.Context, code string, params CourseDistributionParams) (AnalyticsCourseReport, error) {
+	var out AnalyticsCourseReport
+	query := url.Values{}
+	if params.Batch != "" {
+		query.Set("batch", params.Batch)
+	}
+	if params.Programme != "" {
+		query.Set("programme", params.Programme)
+	}
+	if params.Branch != "" {
+		query.Set("branch", params.Branch)
+	}
+	if params.From != 0 {
+		query.Set("from", strconv.FormatInt(params.From, 10))
+	}
+	if params.To != 0 {
+		query.Set("to", strconv.FormatInt(params.To, 10))
+	}
+	if params.Include != "" {
+		query.Set("include", params.Include)
+	}
+	if params.Exclude != "" {
+		query.Set("exclude", params.Exclude)
+	}
+	if params.MinCohort != 0 {
+		query.Set("minCohort", strconv.FormatInt(params.MinCohort, 10))
+	}
+	if params.Semester != "" {
+		query.Set("semester", params.Semester)
+	}
+	if params.GroupBy != "" {
+		query.Set("groupBy", params.GroupBy)
+	}
+	err := c.call(ctx, "GET", "/api/analytics/courses/"+url.PathEscape(code), query, nil, &out)
+	return out, err
+}
+
+// ListAnnouncementsParams are the query parameters of ListAnnouncements.
+type ListAnnouncementsParams struct {
+	Kind   string
+	Since  string
+	Q      string
+	Limit  int64
+	Format string
+}
+
+// ListAnnouncements calls GET /api/announcements: notices, tenders and events of nith.ac.in, newest first, as JSON or an RSS or Atom feed.
+func (c *Client) ListAnnouncements(ctx context.Context, params ListAnnouncementsParams) (AnnouncementList, error) {
+	var out AnnouncementList
+	query := url.Values{}
+	if params.Kind != "" {
+		query.Set("kind", params.Kind)
+	}
+	if params.Since != "" {
+		query.Set("since", params.Since)
+	}
+	if params.Q != "" {
+		query.Set("q", params.Q)
+	}
+	if params.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(params.Limit, 10))
+	}
+	if params.Format != "" {
+		query.Set("format", params.Format)
+	}
+	err := c.call(ctx, "GET", "/api/announcements", query, nil, &out)
+	return out, err
+}
+
+// RefreshAnnouncements calls POST /api/announcements/refresh: scrape the announcement pages again; needs the server identity.
+func (c *Client) RefreshAnnouncements(ctx context.Context) (AnnouncementsRefreshReport, error) {
+	var out AnnouncementsRefreshReport
+	err := c.call(ctx, "POST", "/api/announcements/refresh", nil, nil, &out)
+	return out, err
+}
+
+// AnnouncementStatus calls GET /api/announcements/status: refresh state of every announcement page.
+func (c *Client) AnnouncementStatus(ctx context.Context) (AnnouncementStatus, error) {
+	var out AnnouncementStatus
+	err := c.call(ctx, "GET", "/api/announcements/status", nil, nil, &out)
+	return out, err
+}
+
+// BacklogsParams are the query parameters of Backlogs.
+type BacklogsParams struct {
+	Batch     string
+	Programme string
+	Branch    string
+	From      int64
+	To        int64
+	Include   string
+	Exclude   string
+	Status    string
+}
+
+// Backlogs calls GET /api/backlogs: students of a cohort by academic status, from stored results; up to 10 roll numbers given through include alone are scraped live, answering 206 with the missing ones when the scrape runs out of time.
+func (c *Client) Backlogs(ctx context.Context, params BacklogsParams) (BacklogList, error) {
+	var out BacklogList
+	query := url.Values{}
+	if params.Batch != "" {
+		query.Set("batch", params.Batch)
+	}
+	if params.Programme != "" {
+		query.Set("programme", params.Programme)
+	}
+	if params.Branch != "" {
+		query.Set("branch", params.Branch)
+	}
+	if params.From != 0 {
+		query.Set("from", strconv.FormatInt(params.From, 10))
+	}
+	if params.To != 0 {
+		query.Set("to", strconv.FormatInt(params.To, 10))
+	}
+	if params.Include != "" {
+		query.Set("include", params.Include)
+	}
+	if params.Exclude != "" {
+		query.Set("exclude", params.Exclude)
+	}
+	if params.Status != "" {
+		query.Set("status", params.Status)
+	}
+	err := c.call(ctx, "GET", "/api/backlogs", query, nil, &out)
+	return out, err
+}
+
+// BranchChangesParams are the query parameters of BranchChanges.
+type BranchChangesParams struct {
+	Batch         string
+	Programme     string
+	Branch        string
+	From          int64
+	To            int64
+	Include       string
+	Exclude       string
+	MinConfidence float64
+}
+
+// BranchChanges calls GET /api/branch-changes: students of a cohort whose courses show a branch change, from stored results; up to 10 roll numbers given through include alone are scraped live, answering 206 with the missing ones when the scrape runs out of time.
+func (c *Client) BranchChanges(ctx context.Context, params BranchChangesParams) (BranchChangeList, error) {
+	var out BranchChangeList
+	query := url.Values{}
+	if params.Batch != "" {
+		query.Set("batch", params.Batch)
+	}
+	if params.Programme != "" {
+		query.Set("programme", params.Programme)
+	}
+	if params.Branch != "" {
+		query.Set("branch", params.Branch)
+	}
+	if params.From != 0 {
+		query.Set("from", strconv.FormatInt(params.From, 10))
+	}
+	if params.To != 0 {
+		query.Set("to", strconv.FormatInt(params.To, 10))
+	}
+	if params.Include != "" {
+		query.Set("include", params.Include)
+	}
+	if params.Exclude != "" {
+		query.Set("exclude", params.Exclude)
+	}
+	if params.MinConfidence != 0 {
+		query.Set("minConfidence", strconv.FormatFloat(params.MinConfidence, 'f', -1, 64))
+	}
+	err := c.call(ctx, "GET", "/api/branch-changes", query, nil, &out)
+	return out, err
+}
+
+// BulkScrape calls POST /api/bulk-scrape: fetch the results of several roll numbers.
+func (c *Client) BulkScrape(ctx context.Context, body BulkRequest) ([]ScrapeResult, error) {
+	var out []ScrapeResult
+	err := c.call(ctx, "POST", "/api/bulk-scrape", nil, body, &out)
+	return out, err
+}
+
+// ResolveCohort calls POST /api/cohort: resolve a cohort selector to roll numbers.
+func (c *Client) ResolveCohort(ctx context.Context, body CohortSelector) (CohortRollNumbers, error) {
+	var out CohortRollNumbers
+	err := c.call(ctx, "POST", "/api/cohort", nil, body, &out)
+	return out, err
+}
+
+// SearchCoursesParams are the query parameters of SearchCourses.
+type SearchCoursesParams struct {
+	Q      string
+	Branch string
+	Batch  int64
+}
+
+// SearchCourses calls GET /api/courses: search the course catalogue.
+func (c *Client) SearchCourses(ctx context.Context, params SearchCoursesParams) (CourseList, error) {
+	var out CourseList
+	query := url.Values{}
+	if params.Q != "" {
+		query.Set("q", params.Q)
+	}
+	if params.Branch != "" {
+		query.Set("branch", params.Branch)
+	}
+	if params.Batch != 0 {
+		query.Set("batch", strconv.FormatInt(params.Batch, 10))
+	}
+	err := c.call(ctx, "GET", "/api/courses", query, nil, &out)
+	return out, err
+}
+
+// CourseCreditChanges calls GET /api/courses/credit-changes: courses whose credits changed between schemes or batches.
+func (c *Client) CourseCreditChanges(ctx context.Context) (CourseList, error) {
+	var out CourseList
+	err := c.call(ctx, "GET", "/api/courses/credit-changes", nil, nil, &out)
+	return out, err
+}
+
+// GetCourse calls GET /api/courses/{code}: one course of the catalogue.
+func (c *Client) GetCourse(ctx context.Context, code string) (CatalogCourse, error) {
+	var out CatalogCourse
+	err := c.call(ctx, "GET", "/api/courses/"+url.PathEscape(code), nil, nil, &out)
+	return out, err
+}
+
+// GetCourseBatches calls GET /api/courses/{code}/batches: who took a course in each batch.
+func (c *Client) GetCourseBatches(ctx context.Context, code string) (CourseBatches, error) {
+	var out CourseBatches
+	err := c.call(ctx, "GET", "/api/courses/"+url.PathEscape(code)+"/batches", nil, nil, &out)
+	return out, err
+}
+
+// ListDepartments calls GET /api/departments: every department with its programmes, student and faculty counts.
+func (c *Client) ListDepartments(ctx context.Context) (DepartmentList, error) {
+	var out DepartmentList
+	err := c.call(ctx, "GET", "/api/departments", nil, nil, &out)
+	return out, err
+}
+
+// DepartmentForCourse calls GET /api/departments/by-course/{code}: department offering a course, from its code or prefix.
+func (c *Client) DepartmentForCourse(ctx context.Context, code string) (DepartmentInfo, error) {
+	var out DepartmentInfo
+	err := c.call(ctx, "GET", "/api/departments/by-course/"+url.PathEscape(code), nil, nil, &out)
+	return out, err
+}
+
+// DepartmentForRollNumber calls GET /api/departments/by-roll/{rollNo}: department a roll number was admitted to.
+func (c *Client) DepartmentForRollNumber(ctx context.Context, rollNo string) (DepartmentInfo, error) {
+	var out DepartmentInfo
+	err := c.call(ctx, "GET", "/api/departments/by-roll/"+url.PathEscape(rollNo), nil, nil, &out)
+	return out, err
+}
+
+// GetDepartment calls GET /api/departments/{code}: one department by code.
+func (c *Client) GetDepartment(ctx context.Context, code string) (DepartmentInfo, error) {
+	var out DepartmentInfo
+	err := c.call(ctx, "GET", "/api/departments/"+url.PathEscape(code), nil, nil, &out)
+	return out, err
+}
+
+// ExportResultsParams are the query parameters of ExportResults.
+type ExportResultsParams struct {
+	Batch     string
+	Programme string
+	Branch    string
+	From      int64
+	To        int64
+	Include   string
+	Exclude   string
+	Format    string
+	Rows      string
+	Columns   string
+}
+
+// ExportResults calls GET /api/export: download stored results of a cohort.
+func (c *Client) ExportResults(ctx context.Context, params ExportResultsParams) ([]byte, error) {
+	query := url.Values{}
+	if params.Batch != "" {
+		query.Set("batch", params.Batch)
+	}
+	if params.Programme != "" {
+		query.Set("programme", params.Programme)
+	}
+	if params.Branch != "" {
+		query.Set("branch", params.Branch)
+	}
+	if params.From != 0 {
+		query.Set("from", strconv.FormatInt(params.From, 10))
+	}
+	if params.To != 0 {
+		query.Set("to", strconv.FormatInt(params.To, 10))
+	}
+	if params.Include != "" {
+		query.Set("include", params.Include)
+	}
+	if params.Exclude != "" {
+		query.Set("exclude", params.Exclude)
+	}
+	if params.Format != "" {
+		query.Set("format", params.Format)
+	}
+	if params.Rows != "" {
+		query.Set("rows", params.Rows)
+	}
+	if params.Columns != "" {
+		query.Set("columns", params.Columns)
+	}
+	return c.do(ctx, "GET", "/api/export", query, nil)
+}
+
+// ListFaculty calls GET /api/faculties: faculty of every department.
+func (c *Client) ListFaculty(ctx context.Context) (FacultyList, error) {
+	var out FacultyList
+	err := c.call(ctx, "GET", "/api/faculties", nil, nil, &out)
+	return out, err
+}
+
+// RefreshFaculty calls POST /api/faculties/refresh: scrape the department pages again; needs the server identity.
+func (c *Client) RefreshFaculty(ctx context.Context) (FacultyRefreshReport, error) {
+	var out FacultyRefreshReport
+	err := c.call(ctx, "POST", "/api/faculties/refresh", nil, nil, &out)
+	return out, err
+}
+
+// SearchFacultyParams are the query parameters of SearchFaculty.
+type SearchFacultyParams struct {
+	Q          string
+	Department string
+	Limit      int64
+}
+
+// SearchFaculty calls GET /api/faculties/search: fuzzy search of faculty by name or research area.
+func (c *Client) SearchFaculty(ctx context.Context, params SearchFacultyParams) (FacultySearch, error) {
+	var out FacultySearch
+	query := url.Values{}
+	if params.Q != "" {
+		query.Set("q", params.Q)
+	}
+	if params.Department != "" {
+		query.Set("department", params.Department)
+	}
+	if params.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(params.Limit, 10))
+	}
+	err := c.call(ctx, "GET", "/api/faculties/search", query, nil, &out)
+	return out, err
+}
+
+// GetFacultyByEmail calls GET /api/faculties/search/{email}: faculty member with an email.
+func (c *Client) GetFacultyByEmail(ctx context.Context, email string) (ScrapeFaculty, error) {
+	var out ScrapeFaculty
+	err := c.call(ctx, "GET", "/api/faculties/search/"+url.PathEscape(email), nil, nil, &out)
+	return out, err
+}
+
+// FacultyStatus calls GET /api/faculties/status: refresh state per department and the changes of the latest refresh.
+func (c *Client) FacultyStatus(ctx context.Context) (FacultyStatus, error) {
+	var out FacultyStatus
+	err := c.call(ctx, "GET", "/api/faculties/status", nil, nil, &out)
+	return out, err
+}
+
+// ListDepartmentFaculty calls GET /api/faculties/{departmentCode}: faculty of one department.
+func (c *Client) ListDepartmentFaculty(ctx context.Context, departmentCode string) (FacultyList, error) {
+	var out FacultyList
+	err := c.call(ctx, "GET", "/api/faculties/"+url.PathEscape(departmentCode), nil, nil, &out)
+	return out, err
+}
+
+// GenerateRollNumbersParams are the query parameters of GenerateRollNumbers.
+type GenerateRollNumbersParams struct {
+	Batch     string
+	Programme string
+	Branch    string
+	From      int64
+	To        int64
+	Include   string
+	Exclude   string
+}
+
+// GenerateRollNumbers calls GET /api/generate-roll-numbers: list the roll numbers of a cohort.
+func (c *Client) GenerateRollNumbers(ctx context.Context, params GenerateRollNumbersParams) ([]string, error) {
+	var out []string
+	query := url.Values{}
+	if params.Batch != "" {
+		query.Set("batch", params.Batch)
+	}
+	if params.Programme != "" {
+		query.Set("programme", params.Programme)
+	}
+	if params.Branch != "" {
+		query.Set("branch", params.Branch)
+	}
+	if params.From != 0 {
+		query.Set("from", strconv.FormatInt(params.From, 10))
+	}
+	if params.To != 0 {
+		query.Set("to", strconv.FormatInt(params.To, 10))
+	}
+	if params.Include != "" {
+		query.Set("include", params.Include)
+	}
+	if params.Exclude != "" {
+		query.Set("exclude", params.Exclude)
+	}
+	err := c.call(ctx, "GET", "/api/generate-roll-numbers", query, nil, &out)
+	return out, err
+}
+
+// GradeScalesParams are the query parameters of GradeScales.
+type GradeScalesParams struct {
+	Scheme string
+}
+
+// GradeScales calls GET /api/grade-scales: grade scales in use.
+func (c *Client) GradeScales(ctx context.Context, params GradeScalesParams) ([]GradesScale, error) {
+	var out []GradesScale
+	query := url.Values{}
+	if params.Scheme != "" {
+		query.Set("scheme", params.Scheme)
+	}
+	err := c.call(ctx, "GET", "/api/grade-scales", query, nil, &out)
+	return out, err
+}
+
+// ListHostelsParams are the query parameters of ListHostels.
+type ListHostelsParams struct {
+	Fresh bool
+}
+
+// ListHostels calls GET /api/hostels: hostel in-charges and the functionaries of every hostel.
+func (c *Client) ListHostels(ctx context.Context, params ListHostelsParams) (HostelList, error) {
+	var out HostelList
+	query := url.Values{}
+	if params.Fresh {
+		query.Set("fresh", "true")
+	}
+	err := c.call(ctx, "GET", "/api/hostels", query, nil, &out)
+	return out, err
+}
+
+// AllotHostelRoomsParams are the query parameters of AllotHostelRooms.
+type AllotHostelRoomsParams struct {
+	DryRun bool
+	Format string
+}
+
+// AllotHostelRooms calls POST /api/hostels/allotment: allot rooms to the students of an uploaded CSV or XLSX file.
+func (c *Client) AllotHostelRooms(ctx context.Context, params AllotHostelRoomsParams, body []byte, contentType string) (AllotmentResult, error) {
+	var out AllotmentResult
+	query := url.Values{}
+	if params.DryRun {
+		query.Set("dryRun", "true")
+	}
+	if params.Format != "" {
+		query.Set("format", params.Format)
+	}
+	err := c.call(ctx, "POST", "/api/hostels/allotment", query, upload{data: body, contentType: contentType}, &out)
+	return out, err
+}
+
+// AllotHostelRoomsFromExcelParams are the query parameters of AllotHostelRoomsFromExcel.
+type AllotHostelRoomsFromExcelParams struct {
+	DryRun bool
+	Format string
+}
+
+// AllotHostelRoomsFromExcel calls POST /api/hostels/allotment/rooms-from-excel: same as /api/hostels/allotment, at the path of the Node server.
+func (c *Client) AllotHostelRoomsFromExcel(ctx context.Context, params AllotHostelRoomsFromExcelParams, body []byte, contentType string) (AllotmentResult, error) {
+	var out AllotmentResult
+	query := url.Values{}
+	if params.DryRun {
+		query.Set("dryRun", "true")
+	}
+	if params.Format != "" {
+		query.Set("format", params.Format)
+	}
+	err := c.call(ctx, "POST", "/api/hostels/allotment/rooms-from-excel", query, upload{data: body, contentType: contentType}, &out)
+	return out, err
+}
+
+// DeleteHostelAllotment calls DELETE /api/hostels/allotments/{id}: delete a saved allotment, which is otherwise kept for ALLOTMENT_RETENTION; needs the server identity.
+func (c *Client) DeleteHostelAllotment(ctx context.Context, id string) error {
+	return c.call(ctx, "DELETE", "/api/hostels/allotments/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// GetHostelAllotmentParams are the query parameters of GetHostelAllotment.
+type GetHostelAllotmentParams struct {
+	Format string
+}
+
+// GetHostelAllotment calls GET /api/hostels/allotments/{id}: a saved allotment, as JSON or a CSV or XLSX download.
+func (c *Client) GetHostelAllotment(ctx context.Context, id string, params GetHostelAllotmentParams) (AllotmentResult, error) {
+	var out AllotmentResult
+	query := url.Values{}
+	if params.Format != "" {
+		query.Set("format", params.Format)
+	}
+	err := c.call(ctx, "GET", "/api/hostels/allotments/"+url.PathEscape(id), query, nil, &out)
+	return out, err
+}
+
+// GetHostel calls GET /api/hostels/{slug}: functionaries of one hostel.
+func (c *Client) GetHostel(ctx context.Context, slug string) (ScrapeHostel, error) {
+	var out ScrapeHostel
+	err := c.call(ctx, "GET", "/api/hostels/"+url.PathEscape(slug), nil, nil, &out)
+	return out, err
+}
+
+// ImportResultsParams are the query parameters of ImportResults.
+type ImportResultsParams struct {
+	Format string
+	Map    string
+	Sheet  string
+	Strict bool
+	DryRun bool
+}
+
+// ImportResults calls POST /api/import: import a results dump or a freshers list, as the body or a multipart file; needs the server identity.
+func (c *Client) ImportResults(ctx context.Context, params ImportResultsParams, body []byte, contentType string) (ImporterReport, error) {
+	var out ImporterReport
+	query := url.Values{}
+	if params.Format != "" {
+		query.Set("format", params.Format)
+	}
+	if params.Map != "" {
+		query.Set("map", params.Map)
+	}
+	if params.Sheet != "" {
+		query.Set("sheet", params.Sheet)
+	}
+	if params.Strict {
+		query.Set("strict", "true")
+	}
+	if params.DryRun {
+		query.Set("dryRun", "true")
+	}
+	err := c.call(ctx, "POST", "/api/import", query, upload{data: body, contentType: contentType}, &out)
+	return out, err
+}
+
+// PlanCGPI calls POST /api/planner: project a CGPI under grade changes and future semesters.
+func (c *Client) PlanCGPI(ctx context.Context, body PlannerRequest) (PlannerPlan, error) {
+	var out PlannerPlan
+	err := c.call(ctx, "POST", "/api/planner", nil, body, &out)
+	return out, err
+}
+
+// ListResultsParams are the query parameters of ListResults.
+type ListResultsParams struct {
+	Batch     string
+	Branch    string
+	Programme string
+	MinCGPI   float64
+	MaxCGPI   float64
+	Q         string
+	Sort      string
+	Page      int64
+	Limit     int64
+}
+
+// ListResults calls GET /api/results: search stored results.
+func (c *Client) ListResults(ctx context.Context, params ListResultsParams) (StorePage, error) {
+	var out StorePage
+	query := url.Values{}
+	if params.Batch != "" {
+		query.Set("batch", params.Batch)
+	}
+	if params.Branch != "" {
+		query.Set("branch", params.Branch)
+	}
+	if params.Programme != "" {
+		query.Set("programme", params.Programme)
+	}
+	if params.MinCGPI != 0 {
+		query.Set("minCgpi", strconv.FormatFloat(params.MinCGPI, 'f', -1, 64))
+	}
+	if params.MaxCGPI != 0 {
+		query.Set("maxCgpi", strconv.FormatFloat(params.MaxCGPI, 'f', -1, 64))
+	}
+	if params.Q != "" {
+		query.Set("q", params.Q)
+	}
+	if params.Sort != "" {
+		query.Set("sort", params.Sort)
+	}
+	if params.Page != 0 {
+		query.Set("page", strconv.FormatInt(params.Page, 10))
+	}
+	if params.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(params.Limit, 10))
+	}
+	err := c.call(ctx, "GET", "/api/results", query, nil, &out)
+	return out, err
+}
+
+// GetResultParams are the query parameters of GetResult.
+type GetResultParams struct {
+	Standing bool
+}
+
+// GetResult calls GET /api/results/{rollNo}: stored result of a roll number.
+func (c *Client) GetResult(ctx context.Context, rollNo string, params GetResultParams) (StudentHtmlParsed, error) {
+	var out StudentHtmlParsed
+	query := url.Values{}
+	if params.Standing {
+		query.Set("standing", "true")
+	}
+	err := c.call(ctx, "GET", "/api/results/"+url.PathEscape(rollNo), query, nil, &out)
+	return out, err
+}
+
+// ResultHistory calls GET /api/results/{rollNo}/history: every stored version of a result.
+func (c *Client) ResultHistory(ctx context.Context, rollNo string) ([]StoreRevision, error) {
+	var out []StoreRevision
+	err := c.call(ctx, "GET", "/api/results/"+url.PathEscape(rollNo)+"/history", nil, nil, &out)
+	return out, err
+}
+
+// ScrapeParams are the query parameters of Scrape.
+type ScrapeParams struct {
+	RollNo               string
+	Fresh                bool
+	MaxAge               int64
+	StaleWhileRevalidate int64
+	Standing             bool
+}
+
+// Scrape calls GET /api/scrape: fetch the result of a roll number through the result cache.
+func (c *Client) Scrape(ctx context.Context, params ScrapeParams) (StudentHtmlParsed, error) {
+	var out StudentHtmlParsed
+	query := url.Values{}
+	if params.RollNo != "" {
+		query.Set("rollNo", params.RollNo)
+	}
+	if params.Fresh {
+		query.Set("fresh", "true")
+	}
+	if params.MaxAge != 0 {
+		query.Set("maxAge", strconv.FormatInt(params.MaxAge, 10))
+	}
+	if params.StaleWhileRevalidate != 0 {
+		query.Set("staleWhileRevalidate", strconv.FormatInt(params.StaleWhileRevalidate, 10))
+	}
+	if params.Standing {
+		query.Set("standing", "true")
+	}
+	err := c.call(ctx, "GET", "/api/scrape", query, nil, &out)
+	return out, err
+}
+
+// ScrapeBatchParams are the query parameters of ScrapeBatch.
+type ScrapeBatchParams struct {
+	Batch     string
+	Programme string
+	Branch    string
+	From      int64
+	To        int64
+	Include   string
+	Exclude   string
+	BatchYear string
+}
+
+// ScrapeBatch calls POST /api/scrape-batch: fetch the results of a batch.
+func (c *Client) ScrapeBatch(ctx context.Context, params ScrapeBatchParams) ([]ScrapeResult, error) {
+	var out []ScrapeResult
+	query := url.Values{}
+	if params.Batch != "" {
+		query.Set("batch", params.Batch)
+	}
+	if params.Programme != "" {
+		query.Set("programme", params.Programme)
+	}
+	if params.Branch != "" {
+		query.Set("branch", params.Branch)
+	}
+	if params.From != 0 {
+		query.Set("from", strconv.FormatInt(params.From, 10))
+	}
+	if params.To != 0 {
+		query.Set("to", strconv.FormatInt(params.To, 10))
+	}
+	if params.Include != "" {
+		query.Set("include", params.Include)
+	}
+	if params.Exclude != "" {
+		query.Set("exclude", params.Exclude)
+	}
+	if params.BatchYear != "" {
+		query.Set("batchYear", params.BatchYear)
+	}
+	err := c.call(ctx, "POST", "/api/scrape-batch", query, nil, &out)
+	return out, err
+}
+
+// ScrapeClassParams are the query parameters of ScrapeClass.
+type ScrapeClassParams struct {
+	Batch     string
+	Branch    string
+	Programme string
+}
+
+// ScrapeClass calls GET /api/scrape-class: fetch the results of one branch of one programme in a batch.
+func (c *Client) ScrapeClass(ctx context.Context, params ScrapeClassParams) ([]ScrapeResult, error) {
+	var out []ScrapeResult
+	query := url.Values{}
+	if params.Batch != "" {
+		query.Set("batch", params.Batch)
+	}
+	if params.Branch != "" {
+		query.Set("branch", params.Branch)
+	}
+	if params.Programme != "" {
+		query.Set("programme", params.Programme)
+	}
+	err := c.call(ctx, "GET", "/api/scrape-class", query, nil, &out)
+	return out, err
+}
+
+// ListScrapeJobs calls GET /api/scrape-jobs: list scrape jobs.
+func (c *Client) ListScrapeJobs(ctx context.Context) ([]JobsSummary, error) {
+	var out []JobsSummary
+	err := c.call(ctx, "GET", "/api/scrape-jobs", nil, nil, &out)
+	return out, err
+}
+
+// StartScrapeJob calls POST /api/scrape-jobs: start a background scrape of a cohort; needs the server identity.
+func (c *Client) StartScrapeJob(ctx context.Context, body CohortSelector) (JobsSummary, error) {
+	var out JobsSummary
+	err := c.call(ctx, "POST", "/api/scrape-jobs", nil, body, &out)
+	return out, err
+}
+
+// CancelScrapeJob calls DELETE /api/scrape-jobs/{id}: cancel a scrape job; needs the server identity.
+func (c *Client) CancelScrapeJob(ctx context.Context, id string) (JobsSummary, error) {
+	var out JobsSummary
+	err := c.call(ctx, "DELETE", "/api/scrape-jobs/"+url.PathEscape(id), nil, nil, &out)
+	return out, err
+}
+
+// GetScrapeJobParams are the query parameters of GetScrapeJob.
+type GetScrapeJobParams struct {
+	Results bool
+}
+
+// GetScrapeJob calls GET /api/scrape-jobs/{id}: progress of a scrape job.
+func (c *Client) GetScrapeJob(ctx context.Context, id string, params GetScrapeJobParams) (JobsSummary, error) {
+	var out JobsSummary
+	query := url.Values{}
+	if params.Results {
+		query.Set("results", "true")
+	}
+	err := c.call(ctx, "GET", "/api/scrape-jobs/"+url.PathEscape(id), query, nil, &out)
+	return out, err
+}
+
+// ScrapeJobAbnormalParams are the query parameters of ScrapeJobAbnormal.
+type ScrapeJobAbnormalParams struct {
+	Warnings bool
+}
+
+// ScrapeJobAbnormal calls GET /api/scrape-jobs/{id}/abnormal: records of a job that failed validation.
+func (c *Client) ScrapeJobAbnormal(ctx context.Context, id string, params ScrapeJobAbnormalParams) (AbnormalList, error) {
+	var out AbnormalList
+	query := url.Values{}
+	if params.Warnings {
+		query.Set("warnings", "true")
+	}
+	err := c.call(ctx, "GET", "/api/scrape-jobs/"+url.PathEscape(id)+"/abnormal", query, nil, &out)
+	return out, err
+}
+
+// ExportScrapeJobParams are the query parameters of ExportScrapeJob.
+type ExportScrapeJobParams struct {
+	Format  string
+	Rows    string
+	Columns string
+}
+
+// ExportScrapeJob calls GET /api/scrape-jobs/{id}/export: download the results of a scrape job.
+func (c *Client) ExportScrapeJob(ctx context.Context, id string, params ExportScrapeJobParams) ([]byte, error) {
+	query := url.Values{}
+	if params.Format != "" {
+		query.Set("format", params.Format)
+	}
+	if params.Rows != "" {
+		query.Set("rows", params.Rows)
+	}
+	if params.Columns != "" {
+		query.Set("columns", params.Columns)
+	}
+	return c.do(ctx, "GET", "/api/scrape-jobs/"+url.PathEscape(id)+"/export", query, nil)
+}
+
+// V1BulkScrape calls POST /api/v1/bulk-scrape: version 1: fetch the results of several roll numbers.
+func (c *Client) V1BulkScrape(ctx context.Context, body V1BulkRequest) ([]V1ScrapeResult, error) {
+	var out []V1ScrapeResult
+	err := c.call(ctx, "POST", "/api/v1/bulk-scrape", nil, body, &out)
+	return out, err
+}
+
+// V1ListResultsParams are the query parameters of V1ListResults.
+type V1ListResultsParams struct {
+	Batch     string
+	Branch    string
+	Programme string
+	MinCGPI   float64
+	MaxCGPI   float64
+	Q         string
+	Sort      string
+	Page      int64
+	Limit     int64
+}
+
+// V1ListResults calls GET /api/v1/results: version 1: search stored results.
+func (c *Client) V1ListResults(ctx context.Context, params V1ListResultsParams) (V1Page, error) {
+	var out V1Page
+	query := url.Values{}
+	if params.Batch != "" {
+		query.Set("batch", params.Batch)
+	}
+	if params.Branch != "" {
+		query.Set("branch", params.Branch)
+	}
+	if params.Programme != "" {
+		query.Set("programme", params.Programme)
+	}
+	if params.MinCGPI != 0 {
+		query.Set("minCgpi", strconv.FormatFloat(params.MinCGPI, 'f', -1, 64))
+	}
+	if params.MaxCGPI != 0 {
+		query.Set("maxCgpi", strconv.FormatFloat(params.MaxCGPI, 'f', -1, 64))
+	}
+	if params.Q != "" {
+		query.Set("q", params.Q)
+	}
+	if params.Sort != "" {
+		query.Set("sort", params.Sort)
+	}
+	if params.Page != 0 {
+		query.Set("page", strconv.FormatInt(params.Page, 10))
+	}
+	if params.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(params.Limit, 10))
+	}
+	err := c.call(ctx, "GET", "/api/v1/results", query, nil, &out)
+	return out, err
+}
+
+// V1GetResult calls GET /api/v1/results/{rollNo}: version 1: stored result of a roll number.
+func (c *Client) V1GetResult(ctx context.Context, rollNo string) (V1Student, error) {
+	var out V1Student
+	err := c.call(ctx, "GET", "/api/v1/results/"+url.PathEscape(rollNo), nil, nil, &out)
+	return out, err
+}
+
+// V1ScrapeParams are the query parameters of V1Scrape.
+type V1ScrapeParams struct {
+	RollNo               string
+	Fresh                bool
+	MaxAge               int64
+	StaleWhileRevalidate int64
+}
+
+// V1Scrape calls GET /api/v1/scrape: version 1: fetch the result of a roll number.
+func (c *Client) V1Scrape(ctx context.Context, params V1ScrapeParams) (V1Student, error) {
+	var out V1Student
+	query := url.Values{}
+	if params.RollNo != "" {
+		query.Set("rollNo", params.RollNo)
+	}
+	if params.Fresh {
+		query.Set("fresh", "true")
+	}
+	if params.MaxAge != 0 {
+		query.Set("maxAge", strconv.FormatInt(params.MaxAge, 10))
+	}
+	if params.StaleWhileRevalidate != 0 {
+		query.Set("staleWhileRevalidate", strconv.FormatInt(params.StaleWhileRevalidate, 10))
+	}
+	err := c.call(ctx, "GET", "/api/v1/scrape", query, nil, &out)
+	return out, err
+}
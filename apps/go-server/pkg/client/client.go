@@ -0,0 +1,103 @@
+// Package client is a typed Go client of the result server. The request and
+// response types and one method per operation are generated from the OpenAPI
+// document into api_gen.go; run go generate after changing a route.
+package client
+
+//go:generate go run ../../cmd/genclient -o api_gen.go
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Client calls a result server, e.g. New("http://localhost:8080").
+type Client struct {
+	BaseURL string
+	HTTP    *http.Client
+}
+
+func New(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimRight(baseURL, "/"), HTTP: http.DefaultClient}
+}
+
+// Error is a non-2xx response of the server.
+type Error struct {
+	Status  int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d: %s", e.Status, e.Message)
+}
+
+// upload is a request body sent as is rather than encoded as JSON.
+type upload struct {
+	data        []byte
+	contentType string
+}
+
+// do sends a request and returns the body of a successful response.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body any) ([]byte, error) {
+	target := c.BaseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	var reader io.Reader
+	contentType := ""
+	switch body := body.(type) {
+	case nil:
+	case upload:
+		reader, contentType = bytes.NewReader(body.data), body.contentType
+	default:
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader, contentType = bytes.NewReader(raw), "application/json"
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	httpClient := c.HTTP
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		var failure struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(raw, &failure) != nil || failure.Error == "" {
+			failure.Error = strings.TrimSpace(string(raw))
+		}
+		return nil, &Error{Status: resp.StatusCode, Message: failure.Error}
+	}
+	return raw, nil
+}
+
+// call sends a request and decodes a JSON response into out.
+func (c *Client) call(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	raw, err := c.do(ctx, method, path, query, body)
+	if err != nil || out == nil || len(raw) == 0 {
+		return err
+	}
+	return json.Unmarshal(raw, out)
+}
@@ -0,0 +1,37 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// flushEvery bounds how many rows the CSV writer buffers before handing them
+// to the underlying writer.
+const flushEvery = 200
+
+func (e *Exporter) writeCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(e.headers()); err != nil {
+		return err
+	}
+	record := make([]string, len(e.columns))
+	rows := 0
+	err := e.eachRow(e.students, func(values []any) error {
+		for i, v := range values {
+			record[i] = text(v)
+		}
+		if err := out.Write(record); err != nil {
+			return err
+		}
+		if rows++; rows%flushEvery == 0 {
+			out.Flush()
+			return out.Error()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	out.Flush()
+	return out.Error()
+}
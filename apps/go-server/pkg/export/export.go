@@ -0,0 +1,322 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kanakkholwal/go-server/types"
+)
+
+// Output formats.
+const (
+	FormatCSV     = "csv"
+	FormatXLSX    = "xlsx"
+	FormatParquet = "parquet"
+)
+
+// Row layouts: one row per student, or one row per course a student took.
+const (
+	RowsStudents = "students"
+	RowsCourses  = "courses"
+)
+
+// Options choose what an export contains. Columns are column keys, each
+// optionally renamed with "key:Header"; when empty every column of the layout
+// is written with its default header.
+type Options struct {
+	Format  string
+	Rows    string
+	Columns []string
+}
+
+type kind int
+
+const (
+	kindString kind = iota
+	kindInt
+	kindFloat
+	kindBool
+)
+
+// record is what a column reads its value from. course and semester are only
+// set for the course layout.
+type record struct {
+	student   *types.StudentHtmlParsed
+	semesters map[string]*types.SemesterResult
+	semester  *types.SemesterResult
+	course    *types.SubjectResult
+}
+
+type column struct {
+	key    string
+	header string
+	kind   kind
+	value  func(r *record) any
+}
+
+func studentColumns() []column {
+	return []column{
+		{"rollNo", "Roll No", kindString, func(r *record) any { return r.student.RollNumber }},
+		{"name", "Name", kindString, func(r *record) any { return r.student.Name }},
+		{"fatherName", "Father's Name", kindString, func(r *record) any { return r.student.FathersName }},
+		{"batch", "Batch", kindInt, func(r *record) any { return int64(r.student.Batch) }},
+		{"programme", "Programme", kindString, func(r *record) any { return r.student.Programme }},
+		{"branch", "Branch", kindString, func(r *record) any { return r.student.Branch }},
+		{"cgpi", "CGPI", kindFloat, func(r *record) any { return r.student.CGPI }},
+	}
+}
+
+func summaryColumns() []column {
+	return []column{
+		{"semesters", "Semesters", kindInt, func(r *record) any { return int64(len(r.student.SemesterResults)) }},
+		{"activeBacklogs", "Active Backlogs", kindInt, func(r *record) any {
+			if r.student.AcademicStatus == nil {
+				return nil
+			}
+			return int64(r.student.AcademicStatus.ActiveBacklogs)
+		}},
+		{"abnormal", "Abnormal", kindBool, func(r *record) any { return r.student.Abnormal }},
+	}
+}
+
+func courseColumns() []column {
+	return []column{
+		{"semester", "Semester", kindString, func(r *record) any { return r.semester.SemesterNumber }},
+		{"phase", "Phase", kindString, func(r *record) any { return r.semester.Phase }},
+		{"code", "Course Code", kindString, func(r *record) any { return r.course.SubjectCode }},
+		{"course", "Course", kindString, func(r *record) any { return r.course.SubjectName }},
+		{"credit", "Credit", kindInt, func(r *record) any { return r.course.Credit }},
+		{"grade", "Grade", kindString, func(r *record) any { return r.course.Grade }},
+		{"points", "Points", kindInt, func(r *record) any { return r.course.Points }},
+		{"sgpi", "SGPI", kindFloat, func(r *record) any { return r.semester.SGPI }},
+	}
+}
+
+// semesterColumn resolves the per semester keys sgpi_<n>, cgpi_<n> and
+// credits_<n> of the student layout.
+func semesterColumn(key string) (column, bool) {
+	metric, label, ok := strings.Cut(key, "_")
+	if !ok || label == "" {
+		return column{}, false
+	}
+	lookup := func(r *record) *types.SemesterResult { return r.semesters[label] }
+	switch metric {
+	case "sgpi":
+		return column{key, "SGPI " + label, kindFloat, func(r *record) any {
+			if sem := lookup(r); sem != nil {
+				return sem.SGPI
+			}
+			return nil
+		}}, true
+	case "cgpi":
+		return column{key, "CGPI " + label, kindFloat, func(r *record) any {
+			if sem := lookup(r); sem != nil {
+				return sem.CGPI
+			}
+			return nil
+		}}, true
+	case "credits":
+		return column{key, "Credits " + label, kindInt, func(r *record) any {
+			sem := lookup(r)
+			if sem == nil {
+				return nil
+			}
+			var credits int64
+			for _, subject := range sem.SubjectResults {
+				credits += subject.Credit
+			}
+			return credits
+		}}, true
+	}
+	return column{}, false
+}
+
+// Exporter writes a fixed set of students in one format. Build it with
+// Prepare so option errors surface before any output is written.
+type Exporter struct {
+	opts     Options
+	columns  []column
+	students []types.StudentHtmlParsed
+}
+
+// Prepare validates the options against the students to export.
+func Prepare(students []types.StudentHtmlParsed, opts Options) (*Exporter, error) {
+	if opts.Format == "" {
+		opts.Format = FormatCSV
+	}
+	if opts.Rows == "" {
+		opts.Rows = RowsStudents
+	}
+	switch opts.Format {
+	case FormatCSV, FormatXLSX, FormatParquet:
+	default:
+		return nil, fmt.Errorf("unknown export format %q, expected csv, xlsx or parquet", opts.Format)
+	}
+
+	available := studentColumns()
+	switch opts.Rows {
+	case RowsStudents:
+		available = append(available, summaryColumns()...)
+		for _, label := range semesterLabels(students) {
+			for _, metric := range []string{"sgpi", "cgpi"} {
+				col, _ := semesterColumn(metric + "_" + label)
+				available = append(available, col)
+			}
+		}
+	case RowsCourses:
+		available = append(available, courseColumns()...)
+	default:
+		return nil, fmt.Errorf("unknown row layout %q, expected students or courses", opts.Rows)
+	}
+
+	columns := available
+	if len(opts.Columns) > 0 {
+		byKey := map[string]column{}
+		for _, col := range available {
+			byKey[col.key] = col
+		}
+		columns = make([]column, 0, len(opts.Columns))
+		for _, spec := range opts.Columns {
+			key, header, renamed := strings.Cut(spec, ":")
+			key = strings.TrimSpace(key)
+			col, ok := byKey[key]
+			if !ok && opts.Rows == RowsStudents {
+				col, ok = semesterColumn(key)
+			}
+			if !ok {
+				return nil, fmt.Errorf("unknown %s column %q", strings.TrimSuffix(opts.Rows, "s"), key)
+			}
+			if renamed && strings.TrimSpace(header) != "" {
+				col.header = strings.TrimSpace(header)
+			}
+			columns = append(columns, col)
+		}
+	}
+	if opts.Format == FormatParquet {
+		seen := map[string]bool{}
+		for _, col := range columns {
+			if seen[col.header] {
+				return nil, fmt.Errorf("parquet columns need unique headers, %q is used twice", col.header)
+			}
+			seen[col.header] = true
+		}
+	}
+	return &Exporter{opts: opts, columns: columns, students: students}, nil
+}
+
+// ContentType is the MIME type of the export.
+func (e *Exporter) ContentType() string {
+	switch e.opts.Format {
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case FormatParquet:
+		return "application/vnd.apache.parquet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Filename suggests a download name for the export.
+func (e *Exporter) Filename(base string) string {
+	if e.opts.Rows == RowsCourses {
+		base += "-courses"
+	}
+	return base + "." + e.opts.Format
+}
+
+// Write writes the export to w. Rows are produced one student at a time, so
+// CSV and Parquet output reaches w while later rows are still being built.
+func (e *Exporter) Write(w io.Writer) error {
+	switch e.opts.Format {
+	case FormatXLSX:
+		return e.writeXLSX(w)
+	case FormatParquet:
+		return e.writeParquet(w)
+	}
+	return e.writeCSV(w)
+}
+
+func (e *Exporter) headers() []string {
+	headers := make([]string, len(e.columns))
+	for i, col := range e.columns {
+		headers[i] = col.header
+	}
+	return headers
+}
+
+// eachRow calls fn with the column values of every row of the given students.
+func (e *Exporter) eachRow(students []types.StudentHtmlParsed, fn func(values []any) error) error {
+	values := make([]any, len(e.columns))
+	emit := func(r *record) error {
+		for i, col := range e.columns {
+			values[i] = col.value(r)
+		}
+		return fn(values)
+	}
+	for i := range students {
+		r := &record{student: &students[i], semesters: map[string]*types.SemesterResult{}}
+		for j := range r.student.SemesterResults {
+			sem := &r.student.SemesterResults[j]
+			r.semesters[sem.SemesterNumber] = sem
+		}
+		if e.opts.Rows == RowsStudents {
+			if err := emit(r); err != nil {
+				return err
+			}
+			continue
+		}
+		for j := range r.student.SemesterResults {
+			r.semester = &r.student.SemesterResults[j]
+			for k := range r.semester.SubjectResults {
+				r.course = &r.semester.SubjectResults[k]
+				if err := emit(r); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// semesterLabels lists the semesters present in any of the students, in
+// numeric order.
+func semesterLabels(students []types.StudentHtmlParsed) []string {
+	seen := map[string]bool{}
+	labels := []string{}
+	for _, student := range students {
+		for _, sem := range student.SemesterResults {
+			if !seen[sem.SemesterNumber] {
+				seen[sem.SemesterNumber] = true
+				labels = append(labels, sem.SemesterNumber)
+			}
+		}
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		a, errA := strconv.Atoi(labels[i])
+		b, errB := strconv.Atoi(labels[j])
+		if errA == nil && errB == nil {
+			return a < b
+		}
+		return labels[i] < labels[j]
+	})
+	return labels
+}
+
+// text renders a value for CSV; missing values become empty cells.
+func text(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return fmt.Sprint(v)
+}
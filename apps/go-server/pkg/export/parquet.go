@@ -0,0 +1,71 @@
+package export
+
+import (
+	"io"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// parquetBatch is how many rows are handed to the parquet writer at once.
+const parquetBatch = 500
+
+// writeParquet writes a flat schema with one optional column per export
+// column, named after its header. Parquet orders group fields by name, so
+// values are placed by the column index the schema assigns.
+func (e *Exporter) writeParquet(w io.Writer) error {
+	group := parquet.Group{}
+	for _, col := range e.columns {
+		group[col.header] = parquet.Optional(parquetNode(col.kind))
+	}
+	schema := parquet.NewSchema("results", group)
+	index := map[string]int{}
+	for i, field := range schema.Fields() {
+		index[field.Name()] = i
+	}
+
+	out := parquet.NewWriter(w, schema)
+	rows := make([]parquet.Row, 0, parquetBatch)
+	flush := func() error {
+		if len(rows) == 0 {
+			return nil
+		}
+		_, err := out.WriteRows(rows)
+		rows = rows[:0]
+		return err
+	}
+	err := e.eachRow(e.students, func(values []any) error {
+		row := make(parquet.Row, len(index))
+		for i, v := range values {
+			columnIndex := index[e.columns[i].header]
+			if v == nil {
+				row[columnIndex] = parquet.NullValue().Level(0, 0, columnIndex)
+			} else {
+				row[columnIndex] = parquet.ValueOf(v).Level(0, 1, columnIndex)
+			}
+		}
+		rows = append(rows, row)
+		if len(rows) == parquetBatch {
+			return flush()
+		}
+		return nil
+	})
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+func parquetNode(k kind) parquet.Node {
+	switch k {
+	case kindInt:
+		return parquet.Int(64)
+	case kindFloat:
+		return parquet.Leaf(parquet.DoubleType)
+	case kindBool:
+		return parquet.Leaf(parquet.BooleanType)
+	}
+	return parquet.String()
+}
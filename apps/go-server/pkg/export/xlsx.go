@@ -0,0 +1,99 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/kanakkholwal/go-server/types"
+)
+
+// writeXLSX writes one sheet per branch, sorted by branch name. Sheets are
+// written through excelize stream writers, which spill large sheets to disk.
+func (e *Exporter) writeXLSX(w io.Writer) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	byBranch := map[string][]types.StudentHtmlParsed{}
+	for _, student := range e.students {
+		byBranch[student.Branch] = append(byBranch[student.Branch], student)
+	}
+	branches := make([]string, 0, len(byBranch))
+	for branch := range byBranch {
+		branches = append(branches, branch)
+	}
+	sort.Strings(branches)
+	if len(branches) == 0 {
+		branches = append(branches, "")
+	}
+
+	headers := make([]any, len(e.columns))
+	for i, header := range e.headers() {
+		headers[i] = header
+	}
+	used := map[string]bool{}
+	for i, branch := range branches {
+		sheet := sheetName(branch, used)
+		if i == 0 {
+			if err := f.SetSheetName(f.GetSheetName(0), sheet); err != nil {
+				return err
+			}
+		} else if _, err := f.NewSheet(sheet); err != nil {
+			return err
+		}
+		sw, err := f.NewStreamWriter(sheet)
+		if err != nil {
+			return err
+		}
+		if err := sw.SetRow("A1", headers); err != nil {
+			return err
+		}
+		row := 1
+		err = e.eachRow(byBranch[branch], func(values []any) error {
+			row++
+			cell, err := excelize.CoordinatesToCellName(1, row)
+			if err != nil {
+				return err
+			}
+			return sw.SetRow(cell, values)
+		})
+		if err != nil {
+			return err
+		}
+		if err := sw.Flush(); err != nil {
+			return err
+		}
+	}
+	return f.Write(w)
+}
+
+// sheetName makes a branch name usable as a unique sheet name: at most 31
+// characters and none of : \ / ? * [ ].
+func sheetName(branch string, used map[string]bool) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`:\/?*[]`, r) {
+			return '-'
+		}
+		return r
+	}, strings.TrimSpace(branch))
+	if name == "" {
+		name = "Unknown"
+	}
+	if len(name) > 31 {
+		name = name[:31]
+	}
+	base := name
+	for i := 2; used[strings.ToLower(name)]; i++ {
+		suffix := fmt.Sprintf(" (%d)", i)
+		if len(base)+len(suffix) > 31 {
+			name = base[:31-len(suffix)] + suffix
+		} else {
+			name = base + suffix
+		}
+	}
+	used[strings.ToLower(name)] = true
+	return name
+}
@@ -0,0 +1,244 @@
+package faculty
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kanakkholwal/go-server/constants"
+	"github.com/kanakkholwal/go-server/pkg/refresher"
+	"github.com/kanakkholwal/go-server/pkg/scrape"
+)
+
+// documentName is the store document the directory is persisted as.
+const documentName = "faculty"
+
+// Fetcher scrapes the faculty of every department, returning the faculty of
+// the departments that worked with a scrape.DepartmentErrors for the rest.
+type Fetcher func(ctx context.Context) ([]scrape.Faculty, error)
+
+// Department is the refresh state of one department.
+type Department struct {
+	Code  string `json:"code"`
+	Count int    `json:"count"`
+	// RefreshedAt is the last refresh that reached the department's page.
+	RefreshedAt *time.Time `json:"refreshedAt,omitempty"`
+	// Error is why the latest refresh failed for the department; its faculty
+	// is then kept from the refresh before.
+	Error string `json:"error,omitempty"`
+}
+
+// Diff lists the faculty that changed in one refresh, matched by email.
+type Diff struct {
+	Since   *time.Time       `json:"since,omitempty"`
+	At      time.Time        `json:"at"`
+	Added   []scrape.Faculty `json:"added"`
+	Removed []scrape.Faculty `json:"removed"`
+	Changed []scrape.Faculty `json:"changed"`
+}
+
+// Snapshot is the directory as of its latest refresh.
+type Snapshot struct {
+	RefreshedAt *time.Time       `json:"refreshedAt,omitempty"`
+	Faculty     []scrape.Faculty `json:"faculty"`
+	Departments []Department     `json:"departments"`
+	LastDiff    *Diff            `json:"lastDiff,omitempty"`
+}
+
+// Directory is the faculty of every department, refreshed from the
+// department pages and persisted after every refresh.
+type Directory struct {
+	mu        sync.RWMutex
+	snap      Snapshot
+	byEmail   map[string]int
+	fetch     Fetcher
+	refresher refresher.Refresher
+}
+
+// New loads the directory saved in persist. fetch defaults to
+// scrape.GetFacultyList. An unreadable saved directory is logged and the
+// directory starts empty; the next refresh replaces it.
+func New(persist refresher.Persister, fetch Fetcher) *Directory {
+	if fetch == nil {
+		fetch = scrape.GetFacultyList
+	}
+	d := &Directory{fetch: fetch, refresher: refresher.Refresher{Name: "faculty", Document: documentName, Persist: persist}}
+	var snap Snapshot
+	if !d.refresher.Load(&snap) {
+		snap = Snapshot{}
+	}
+	d.set(snap)
+	return d
+}
+
+func (d *Directory) set(snap Snapshot) {
+	if snap.Faculty == nil {
+		snap.Faculty = []scrape.Faculty{}
+	}
+	if snap.Departments == nil {
+		snap.Departments = []Department{}
+	}
+	byEmail := make(map[string]int, len(snap.Faculty))
+	for i, f := range snap.Faculty {
+		byEmail[strings.ToLower(f.Email)] = i
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.snap = snap
+	d.byEmail = byEmail
+}
+
+// Snapshot returns the directory as of its latest refresh.
+func (d *Directory) Snapshot() Snapshot {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.snap
+}
+
+// Department returns the faculty of one department code, ordered by name.
+func (d *Directory) Department(code string) []scrape.Faculty {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	out := []scrape.Faculty{}
+	for _, f := range d.snap.Faculty {
+		if strings.EqualFold(f.Department, code) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// ByEmail looks a faculty member up by email, ignoring case.
+func (d *Directory) ByEmail(email string) (scrape.Faculty, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	i, ok := d.byEmail[strings.ToLower(strings.TrimSpace(email))]
+	if !ok {
+		return scrape.Faculty{}, false
+	}
+	return d.snap.Faculty[i], true
+}
+
+// RefreshReport is the outcome of one refresh.
+type RefreshReport struct {
+	RefreshedAt time.Time         `json:"refreshedAt"`
+	Count       int               `json:"count"`
+	Diff        Diff              `json:"diff"`
+	Failed      map[string]string `json:"failed,omitempty"`
+}
+
+// Refresh scrapes the department pages again. Departments whose page fails
+// keep their faculty from the previous refresh, so a flaky page does not show
+// up as everyone leaving; they are listed in the report. Nothing changes when
+// every department fails. A refresh that is already running makes it return a
+// refresher.ErrRefreshing error.
+func (d *Directory) Refresh(ctx context.Context) (report *RefreshReport, err error) {
+	err = d.refresher.Run(func() error {
+		report, err = d.refresh(ctx)
+		return err
+	})
+	return report, err
+}
+
+func (d *Directory) refresh(ctx context.Context) (*RefreshReport, error) {
+	fetched, err := d.fetch(ctx)
+	var deptErrs scrape.DepartmentErrors
+	if err != nil && !errors.As(err, &deptErrs) {
+		return nil, err
+	}
+	failed := map[string]string{}
+	for _, e := range deptErrs {
+		failed[e.Department] = e.Err.Error()
+	}
+	if len(failed) >= len(constants.DepartmentsList) {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	prev := d.Snapshot()
+	next := Snapshot{RefreshedAt: &now, Faculty: fetched}
+	for _, f := range prev.Faculty {
+		if _, kept := failed[f.Department]; kept {
+			next.Faculty = append(next.Faculty, f)
+		}
+	}
+	sort.SliceStable(next.Faculty, func(i, j int) bool {
+		if next.Faculty[i].Department != next.Faculty[j].Department {
+			return next.Faculty[i].Department < next.Faculty[j].Department
+		}
+		return next.Faculty[i].Name < next.Faculty[j].Name
+	})
+
+	prevDepartments := map[string]Department{}
+	for _, dept := range prev.Departments {
+		prevDepartments[dept.Code] = dept
+	}
+	counts := map[string]int{}
+	for _, f := range next.Faculty {
+		counts[f.Department]++
+	}
+	for _, department := range constants.DepartmentsList {
+		dept := Department{Code: department.Code, Count: counts[department.Code], RefreshedAt: &now}
+		if msg, ok := failed[department.Code]; ok {
+			dept.RefreshedAt = prevDepartments[department.Code].RefreshedAt
+			dept.Error = msg
+		}
+		next.Departments = append(next.Departments, dept)
+	}
+
+	diff := diffFaculty(prev.Faculty, next.Faculty)
+	diff.Since, diff.At = prev.RefreshedAt, now
+	next.LastDiff = &diff
+
+	if err := d.refresher.Save(next); err != nil {
+		return nil, err
+	}
+	d.set(next)
+	report := &RefreshReport{RefreshedAt: now, Count: len(next.Faculty), Diff: diff}
+	if len(failed) > 0 {
+		report.Failed = failed
+	}
+	return report, nil
+}
+
+// Schedule refreshes the directory every interval until ctx is done, each
+// refresh bounded by timeout; see refresher.Refresher.Schedule.
+func (d *Directory) Schedule(ctx context.Context, interval, timeout time.Duration) {
+	d.refresher.Schedule(ctx, interval, timeout, d.Snapshot().RefreshedAt, func(ctx context.Context) (string, error) {
+		report, err := d.Refresh(ctx)
+		if err != nil || len(report.Failed) == 0 {
+			return "", err
+		}
+		return fmt.Sprintf("%d faculty, kept the previous faculty of %s", report.Count, refresher.Keys(report.Failed)), nil
+	})
+}
+
+func diffFaculty(before, after []scrape.Faculty) Diff {
+	diff := Diff{Added: []scrape.Faculty{}, Removed: []scrape.Faculty{}, Changed: []scrape.Faculty{}}
+	old := make(map[string]scrape.Faculty, len(before))
+	for _, f := range before {
+		old[strings.ToLower(f.Email)] = f
+	}
+	for _, f := range after {
+		key := strings.ToLower(f.Email)
+		prev, ok := old[key]
+		delete(old, key)
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, f)
+		case !reflect.DeepEqual(prev, f):
+			diff.Changed = append(diff.Changed, f)
+		}
+	}
+	for _, f := range before {
+		if _, removed := old[strings.ToLower(f.Email)]; removed {
+			diff.Removed = append(diff.Removed, f)
+		}
+	}
+	return diff
+}
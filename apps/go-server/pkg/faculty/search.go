@@ -0,0 +1,135 @@
+package faculty
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/kanakkholwal/go-server/pkg/scrape"
+)
+
+// minScore is the lowest score a search match is reported with.
+const minScore = 0.7
+
+// Match is one search hit. Field is "name" or "researchAreas", whichever
+// matched best; Score runs from minScore to 1.
+type Match struct {
+	Faculty scrape.Faculty `json:"faculty"`
+	Field   string         `json:"field"`
+	Score   float64        `json:"score"`
+}
+
+// Search finds faculty by name or research area, tolerating typos: every
+// query word is compared with the closest word of the field. department
+// optionally narrows the search to one department code. Matches are ordered
+// by score, then name; limit <= 0 returns them all.
+func (d *Directory) Search(query, department string, limit int) []Match {
+	words := searchWords(query)
+	matches := []Match{}
+	if len(words) == 0 {
+		return matches
+	}
+	d.mu.RLock()
+	for _, f := range d.snap.Faculty {
+		if department != "" && !strings.EqualFold(f.Department, department) {
+			continue
+		}
+		best := Match{Faculty: f, Field: "name", Score: fieldScore(words, f.Name)}
+		for _, area := range f.ResearchAreas {
+			// a research area is a weaker hit than the name itself
+			if score := fieldScore(words, area) * 0.9; score > best.Score {
+				best.Field, best.Score = "researchAreas", score
+			}
+		}
+		if best.Score >= minScore {
+			best.Score = float64(int(best.Score*1000+0.5)) / 1000
+			matches = append(matches, best)
+		}
+	}
+	d.mu.RUnlock()
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Faculty.Name < matches[j].Faculty.Name
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// titles are left out of names before matching.
+var titles = map[string]bool{"dr": true, "prof": true, "mr": true, "mrs": true, "ms": true}
+
+func searchWords(s string) []string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	out := words[:0]
+	for _, w := range words {
+		if !titles[w] {
+			out = append(out, w)
+		}
+	}
+	return out
+}
+
+// fieldScore is the mean, over the query words, of their similarity to the
+// closest word of the field. A word that starts a field word counts as a
+// near match, so partial names find their owner.
+func fieldScore(query []string, field string) float64 {
+	words := searchWords(field)
+	if len(words) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, q := range query {
+		best := 0.0
+		for _, w := range words {
+			var score float64
+			switch {
+			case w == q:
+				score = 1
+			case len(q) >= 3 && strings.HasPrefix(w, q):
+				score = 0.9
+			default:
+				score = similarity(q, w)
+			}
+			best = max(best, score)
+		}
+		total += best
+	}
+	return total / float64(len(query))
+}
+
+// similarity is 1 minus the edit distance relative to the longer word.
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,188 @@
+package grades
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/kanakkholwal/go-server/config"
+	"github.com/kanakkholwal/go-server/types"
+)
+
+// Grade is one letter of a grade scale.
+type Grade struct {
+	Letter string  `json:"letter"`
+	Point  float64 `json:"point"`
+	Pass   bool    `json:"pass"`
+	// CountsTowardsCGPI is false for grades whose credits are left out of the
+	// SGPI/CGPI denominator, e.g. incomplete or audit grades.
+	CountsTowardsCGPI bool `json:"countsTowardsCgpi"`
+	// Audit grades belong to non-credit courses and carry no grade point.
+	Audit bool `json:"audit"`
+}
+
+// Scale maps grade letters to grade points for the schemes it applies to.
+// Schemes holds scheme names or prefixes such as "scheme21" or "mtech"; an
+// empty list makes the scale the fallback for every scheme.
+type Scale struct {
+	Name    string   `json:"name"`
+	Schemes []string `json:"schemes"`
+	Grades  []Grade  `json:"grades"`
+
+	index map[string]Grade
+}
+
+// Default is the 10 point scale used by all current NITH schemes. The double
+// letter forms (AA, BB, ...) appear on older result pages.
+var Default = newScale(&Scale{
+	Name: "default",
+	Grades: []Grade{
+		{Letter: "A", Point: 10, Pass: true, CountsTowardsCGPI: true},
+		{Letter: "AA", Point: 10, Pass: true, CountsTowardsCGPI: true},
+		{Letter: "AB", Point: 9, Pass: true, CountsTowardsCGPI: true},
+		{Letter: "B", Point: 8, Pass: true, CountsTowardsCGPI: true},
+		{Letter: "BB", Point: 8, Pass: true, CountsTowardsCGPI: true},
+		{Letter: "BC", Point: 7, Pass: true, CountsTowardsCGPI: true},
+		{Letter: "C", Point: 6, Pass: true, CountsTowardsCGPI: true},
+		{Letter: "CC", Point: 6, Pass: true, CountsTowardsCGPI: true},
+		{Letter: "CD", Point: 5, Pass: true, CountsTowardsCGPI: true},
+		{Letter: "D", Point: 4, Pass: true, CountsTowardsCGPI: true},
+		{Letter: "DD", Point: 4, Pass: true, CountsTowardsCGPI: true},
+		{Letter: "F", Point: 0, Pass: false, CountsTowardsCGPI: true},
+		{Letter: "FF", Point: 0, Pass: false, CountsTowardsCGPI: true},
+		{Letter: "X", Point: 0, Pass: false, CountsTowardsCGPI: true},
+		{Letter: "I", Point: 0, Pass: false, CountsTowardsCGPI: false},
+		{Letter: "S", Point: 0, Pass: true, CountsTowardsCGPI: false, Audit: true},
+		{Letter: "U", Point: 0, Pass: false, CountsTowardsCGPI: false, Audit: true},
+	},
+})
+
+var (
+	mu     sync.RWMutex
+	custom []*Scale
+	once   sync.Once
+)
+
+func newScale(s *Scale) *Scale {
+	s.index = make(map[string]Grade, len(s.Grades))
+	for _, g := range s.Grades {
+		g.Letter = normalise(g.Letter)
+		s.index[g.Letter] = g
+	}
+	return s
+}
+
+func normalise(letter string) string {
+	return strings.ToUpper(strings.TrimSpace(letter))
+}
+
+// Lookup returns the grade for a letter as printed on the result page.
+func (s *Scale) Lookup(letter string) (Grade, bool) {
+	g, ok := s.index[normalise(letter)]
+	return g, ok
+}
+
+// IsFailing reports whether a grade leaves the course uncleared. Unknown
+// letters are not treated as failing.
+func (s *Scale) IsFailing(letter string) bool {
+	g, ok := s.Lookup(letter)
+	return ok && !g.Pass
+}
+
+// GradePoint derives the grade point of a course. Known letters use the scale;
+// for unknown letters the point is recovered from points/credit when the credit
+// is positive. The returned warning is empty unless the letter is unknown.
+// The result is never NaN or Inf.
+func (s *Scale) GradePoint(subject types.SubjectResult) (float64, string) {
+	if g, ok := s.Lookup(subject.Grade); ok {
+		return g.Point, ""
+	}
+	warning := fmt.Sprintf("unknown grade %q for course %s in scale %s", subject.Grade, subject.SubjectCode, s.Name)
+	if subject.Credit > 0 {
+		return float64(subject.Points) / float64(subject.Credit), warning
+	}
+	return 0, warning
+}
+
+// CountsTowardsCGPI reports whether a course's credits belong in the SGPI/CGPI
+// denominator. Courses with unknown grades count, as the portal printed points
+// for them.
+func (s *Scale) CountsTowardsCGPI(subject types.SubjectResult) bool {
+	if subject.Credit <= 0 {
+		return false
+	}
+	g, ok := s.Lookup(subject.Grade)
+	return !ok || (g.CountsTowardsCGPI && !g.Audit)
+}
+
+// ForScheme returns the scale configured for a scheme such as "scheme21",
+// preferring the custom scale with the longest matching scheme prefix and
+// falling back to Default.
+func ForScheme(scheme string) *Scale {
+	loadConfigured()
+	scheme = strings.ToLower(scheme)
+	mu.RLock()
+	defer mu.RUnlock()
+	var best *Scale
+	bestLen := -1
+	for _, s := range custom {
+		if len(s.Schemes) == 0 && bestLen < 0 {
+			best, bestLen = s, 0
+		}
+		for _, prefix := range s.Schemes {
+			prefix = strings.ToLower(prefix)
+			if strings.HasPrefix(scheme, prefix) && len(prefix) > bestLen {
+				best, bestLen = s, len(prefix)
+			}
+		}
+	}
+	if best == nil {
+		return Default
+	}
+	return best
+}
+
+// Scales lists the configured scales followed by Default.
+func Scales() []*Scale {
+	loadConfigured()
+	mu.RLock()
+	defer mu.RUnlock()
+	return append(append([]*Scale(nil), custom...), Default)
+}
+
+// Load replaces the custom scales with the JSON array of scales in path.
+func Load(path string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var scales []*Scale
+	if err := json.Unmarshal(raw, &scales); err != nil {
+		return fmt.Errorf("invalid grade scale file %s: %w", path, err)
+	}
+	for i, s := range scales {
+		if len(s.Grades) == 0 {
+			return fmt.Errorf("grade scale %d (%s) in %s has no grades", i, s.Name, path)
+		}
+		newScale(s)
+	}
+	mu.Lock()
+	custom = scales
+	mu.Unlock()
+	return nil
+}
+
+func loadConfigured() {
+	once.Do(func() {
+		path := config.Get().GradeScalesFile
+		if path == "" {
+			return
+		}
+		if err := Load(path); err != nil {
+			log.Printf("grade scales: %v, using the default scale\n", err)
+		}
+	})
+}
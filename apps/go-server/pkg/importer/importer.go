@@ -0,0 +1,199 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kanakkholwal/go-server/pkg/scrape"
+	"github.com/kanakkholwal/go-server/types"
+	"github.com/kanakkholwal/go-server/utils"
+)
+
+// Input formats.
+const (
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+	FormatCSV    = "csv"
+	FormatXLSX   = "xlsx"
+)
+
+// Schema versions of JSON records. Version 1 dumps number semesters with
+// "semesterNo"; version 2 is the current types.SemesterResult.
+const (
+	SchemaV1 = 1
+	SchemaV2 = 2
+)
+
+// Target receives imported records; store.Store satisfies it.
+type Target interface {
+	Get(rollNo string) (types.StudentHtmlParsed, bool)
+	Upsert(student *types.StudentHtmlParsed)
+}
+
+type Options struct {
+	Format string
+	// Mapping maps record fields (rollNo, name, fatherName, gender, cgpi) to a
+	// column header, or to a 1-based column number, of CSV and XLSX input.
+	// Unmapped fields are found by their usual header names.
+	Mapping map[string]string
+	// Sheet is the XLSX sheet to read; the first sheet by default.
+	Sheet string
+	// Strict skips records that fail validation instead of importing them
+	// with their quality flags.
+	Strict bool
+	// DryRun validates without writing to the target.
+	DryRun bool
+}
+
+// RowIssue describes a problem with one input row. Row numbers are 1-based:
+// the array index for JSON, the line for NDJSON and the sheet row for CSV and
+// XLSX.
+type RowIssue struct {
+	Row          int                 `json:"row"`
+	RollNumber   string              `json:"rollNo,omitempty"`
+	Message      string              `json:"message"`
+	QualityFlags []types.QualityFlag `json:"qualityFlags,omitempty"`
+}
+
+type Report struct {
+	Format         string         `json:"format"`
+	DryRun         bool           `json:"dryRun"`
+	SchemaVersions map[string]int `json:"schemaVersions,omitempty"`
+	Rows           int            `json:"rows"`
+	Imported       int            `json:"imported"`
+	Freshers       int            `json:"freshers"`
+	Skipped        int            `json:"skipped"`
+	// Errors lists the rows that were skipped.
+	Errors []RowIssue `json:"errors"`
+	// Flagged lists imported rows whose result failed validation.
+	Flagged []RowIssue `json:"flagged"`
+}
+
+// row is one decoded input record, or the reason it could not be decoded.
+type row struct {
+	number  int
+	student *types.StudentHtmlParsed
+	schema  int
+	err     error
+}
+
+// Run reads every record of r and upserts the valid ones into target.
+func Run(r io.Reader, opts Options, target Target) (*Report, error) {
+	report := &Report{
+		Format:         opts.Format,
+		DryRun:         opts.DryRun,
+		SchemaVersions: map[string]int{},
+		Errors:         []RowIssue{},
+		Flagged:        []RowIssue{},
+	}
+	var rows []row
+	var err error
+	switch opts.Format {
+	case FormatJSON:
+		rows, err = readJSON(r)
+	case FormatNDJSON:
+		rows, err = readNDJSON(r)
+	case FormatCSV:
+		rows, err = readCSV(r, opts.Mapping)
+	case FormatXLSX:
+		rows, err = readXLSX(r, opts.Sheet, opts.Mapping)
+	default:
+		return nil, fmt.Errorf("unknown import format %q, expected json, ndjson, csv or xlsx", opts.Format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, in := range rows {
+		report.Rows++
+		if in.schema != 0 {
+			report.SchemaVersions[fmt.Sprintf("v%d", in.schema)]++
+		}
+		student, issue := prepare(in, target)
+		if issue != nil {
+			report.Skipped++
+			report.Errors = append(report.Errors, *issue)
+			continue
+		}
+		if student.Abnormal {
+			flagged := RowIssue{Row: in.number, RollNumber: student.RollNumber, Message: "result failed validation", QualityFlags: student.QualityFlags}
+			if opts.Strict {
+				report.Skipped++
+				report.Errors = append(report.Errors, flagged)
+				continue
+			}
+			report.Flagged = append(report.Flagged, flagged)
+		}
+		if len(student.SemesterResults) == 0 {
+			report.Freshers++
+		}
+		report.Imported++
+		if !opts.DryRun {
+			target.Upsert(student)
+		}
+	}
+	return report, nil
+}
+
+// prepare normalises a decoded record. Records with semesters are enriched
+// and validated like scraped results; records without any are freshers, whose
+// branch, batch and programme come from the roll number. A fresher row never
+// replaces stored semesters, it only fills in the name and gender.
+func prepare(in row, target Target) (*types.StudentHtmlParsed, *RowIssue) {
+	if in.err != nil {
+		issue := &RowIssue{Row: in.number, Message: in.err.Error()}
+		if in.student != nil {
+			issue.RollNumber = in.student.RollNumber
+		}
+		return nil, issue
+	}
+	student := in.student
+	info, err := utils.ParseRollNumber(student.RollNumber)
+	if err != nil {
+		return nil, &RowIssue{Row: in.number, RollNumber: student.RollNumber, Message: err.Error()}
+	}
+	student.RollNumber = info.RollNumber
+	student.Name = strings.TrimSpace(student.Name)
+	if student.Batch == 0 {
+		student.Batch = info.Batch
+	}
+	if student.Programme == "" {
+		student.Programme = info.Programme
+	}
+	if student.Branch == "" {
+		student.Branch = utils.DetermineDepartment(info.RollNumber)
+	}
+
+	if len(student.SemesterResults) > 0 {
+		scrape.Enrich(student)
+		return student, nil
+	}
+	if student.Name == "" {
+		return nil, &RowIssue{Row: in.number, RollNumber: student.RollNumber, Message: "name is required"}
+	}
+	if existing, ok := target.Get(student.RollNumber); ok && len(existing.SemesterResults) > 0 {
+		if student.Gender != "" {
+			existing.Gender = student.Gender
+		}
+		if strings.TrimSpace(existing.Name) == "" {
+			existing.Name = student.Name
+		}
+		return &existing, nil
+	}
+	student.SemesterResults = []types.SemesterResult{}
+	return student, nil
+}
+
+// NormalizeGender accepts the spellings found in freshers lists.
+func NormalizeGender(raw string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "m", "male", "boy":
+		return "male", nil
+	case "f", "female", "girl":
+		return "female", nil
+	case "", "-", "na", "n/a", "not_specified", "not specified", "other":
+		return "not_specified", nil
+	}
+	return "", fmt.Errorf("unknown gender %q", raw)
+}
@@ -0,0 +1,110 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/kanakkholwal/go-server/types"
+)
+
+// record accepts both a bare student and the {rollNumber, data, error}
+// wrapper the bulk scrape routes return. Semesters are decoded leniently so
+// version 1 dumps can be upgraded.
+type record struct {
+	types.StudentHtmlParsed
+	Semesters []legacySemester `json:"semesters"`
+
+	WrappedRoll string          `json:"rollNumber"`
+	Data        json.RawMessage `json:"data"`
+	Error       string          `json:"error"`
+}
+
+type legacySemester struct {
+	types.SemesterResult
+	SemesterNo json.Number `json:"semesterNo"`
+}
+
+func readJSON(r io.Reader) ([]row, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	raw = bytes.TrimSpace(raw)
+	// a single record is imported as a one element dump
+	if len(raw) > 0 && raw[0] == '{' {
+		return []row{decodeRecord(1, raw)}, nil
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, fmt.Errorf("invalid JSON dump: %w", err)
+	}
+	rows := make([]row, 0, len(items))
+	for i, item := range items {
+		rows = append(rows, decodeRecord(i+1, item))
+	}
+	return rows, nil
+}
+
+func readNDJSON(r io.Reader) ([]row, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	rows := []row{}
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		rows = append(rows, decodeRecord(line, text))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func decodeRecord(number int, raw []byte) row {
+	var rec record
+	if err := json.Unmarshal(raw, &rec); err != nil {
+		return row{number: number, err: fmt.Errorf("invalid record: %w", err)}
+	}
+	if rec.WrappedRoll != "" || rec.Data != nil {
+		if rec.Error != "" {
+			return row{number: number, student: &types.StudentHtmlParsed{RollNumber: rec.WrappedRoll}, err: errors.New(strings.TrimSpace(rec.Error))}
+		}
+		if len(rec.Data) == 0 || string(rec.Data) == "null" {
+			return row{number: number, student: &types.StudentHtmlParsed{RollNumber: rec.WrappedRoll}, err: errors.New("record has no data")}
+		}
+		return decodeRecord(number, rec.Data)
+	}
+
+	student := rec.StudentHtmlParsed
+	schema := SchemaV2
+	student.SemesterResults = make([]types.SemesterResult, 0, len(rec.Semesters))
+	for _, sem := range rec.Semesters {
+		if sem.SemesterNumber == "" && sem.SemesterNo != "" {
+			schema = SchemaV1
+			n, err := strconv.Atoi(sem.SemesterNo.String())
+			if err != nil {
+				return row{number: number, schema: schema, err: fmt.Errorf("%s: invalid semesterNo %q", student.RollNumber, sem.SemesterNo)}
+			}
+			sem.SemesterNumber = strconv.Itoa(n)
+		}
+		student.SemesterResults = append(student.SemesterResults, sem.SemesterResult)
+	}
+	if student.Gender != "" {
+		gender, err := NormalizeGender(student.Gender)
+		if err != nil {
+			return row{number: number, schema: schema, err: err}
+		}
+		student.Gender = gender
+	}
+	// fields derived on import are recomputed rather than trusted
+	student.Phases, student.AcademicStatus, student.BranchInfo, student.QualityFlags = nil, nil, nil, nil
+	return row{number: number, student: &student, schema: schema}
+}
@@ -0,0 +1,174 @@
+package importer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/kanakkholwal/go-server/types"
+)
+
+// headerAliases are the header names each field is recognised by when no
+// mapping is given, compared case-insensitively ignoring punctuation.
+var headerAliases = map[string][]string{
+	"rollNo":     {"rollno", "rollnumber", "roll", "enrollmentno", "enrolmentno"},
+	"name":       {"name", "studentname", "nameofstudent", "fullname"},
+	"fatherName": {"fathername", "fathersname"},
+	"gender":     {"gender", "sex"},
+	"cgpi":       {"cgpi", "cgpa"},
+}
+
+func readCSV(r io.Reader, mapping map[string]string) ([]row, error) {
+	records, err := ReadTable(r, FormatCSV, "")
+	if err != nil {
+		return nil, err
+	}
+	return tableRows(records, mapping)
+}
+
+func readXLSX(r io.Reader, sheet string, mapping map[string]string) ([]row, error) {
+	records, err := ReadTable(r, FormatXLSX, sheet)
+	if err != nil {
+		return nil, err
+	}
+	return tableRows(records, mapping)
+}
+
+// ReadTable reads every row of a CSV file or of one XLSX sheet, the first
+// sheet when sheet is empty. Rows may differ in length.
+func ReadTable(r io.Reader, format, sheet string) ([][]string, error) {
+	switch format {
+	case FormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		records, err := reader.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		return records, nil
+	case FormatXLSX:
+		f, err := excelize.OpenReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid XLSX: %w", err)
+		}
+		defer f.Close()
+		if sheet == "" {
+			sheet = f.GetSheetName(0)
+		}
+		records, err := f.GetRows(sheet)
+		if err != nil {
+			return nil, fmt.Errorf("read sheet %q: %w", sheet, err)
+		}
+		return records, nil
+	}
+	return nil, fmt.Errorf("unknown table format %q, expected csv or xlsx", format)
+}
+
+// tableRows turns spreadsheet rows into records. The first row is the header;
+// blank rows are ignored.
+func tableRows(records [][]string, mapping map[string]string) ([]row, error) {
+	if len(records) == 0 {
+		return nil, fmt.Errorf("the file is empty")
+	}
+	columns, err := mapColumns(records[0], mapping)
+	if err != nil {
+		return nil, err
+	}
+	cell := func(record []string, field string) string {
+		i, ok := columns[field]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	rows := []row{}
+	for i, record := range records[1:] {
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		number := i + 2
+		student := &types.StudentHtmlParsed{
+			RollNumber:  cell(record, "rollNo"),
+			Name:        cell(record, "name"),
+			FathersName: cell(record, "fatherName"),
+		}
+		gender, err := NormalizeGender(cell(record, "gender"))
+		if err != nil {
+			rows = append(rows, row{number: number, err: err})
+			continue
+		}
+		student.Gender = gender
+		if raw := cell(record, "cgpi"); raw != "" {
+			if student.CGPI, err = strconv.ParseFloat(raw, 64); err != nil {
+				rows = append(rows, row{number: number, err: fmt.Errorf("invalid CGPI %q", raw)})
+				continue
+			}
+		}
+		rows = append(rows, row{number: number, student: student})
+	}
+	return rows, nil
+}
+
+// mapColumns finds the column index of every field. Explicit mappings name a
+// header or a 1-based column number; the roll number column is required.
+func mapColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	columns := map[string]int{}
+	find := func(name string) (int, bool) {
+		for i, h := range header {
+			if Squash(h) == Squash(name) {
+				return i, true
+			}
+		}
+		return 0, false
+	}
+	for field, target := range mapping {
+		if _, ok := headerAliases[field]; !ok {
+			return nil, fmt.Errorf("unknown field %q in column mapping", field)
+		}
+		if n, err := strconv.Atoi(target); err == nil {
+			if n < 1 {
+				return nil, fmt.Errorf("column number for %s must be at least 1", field)
+			}
+			columns[field] = n - 1
+			continue
+		}
+		i, ok := find(target)
+		if !ok {
+			return nil, fmt.Errorf("column %q mapped to %s is not in the header", target, field)
+		}
+		columns[field] = i
+	}
+	for field, aliases := range headerAliases {
+		if _, ok := columns[field]; ok {
+			continue
+		}
+		for _, alias := range aliases {
+			if i, ok := find(alias); ok {
+				columns[field] = i
+				break
+			}
+		}
+	}
+	if _, ok := columns["rollNo"]; !ok {
+		return nil, fmt.Errorf("no roll number column found, map one with rollNo")
+	}
+	return columns, nil
+}
+
+// Squash lowercases s and drops everything but letters and digits, the form
+// headers are compared in.
+func Squash(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,216 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kanakkholwal/go-server/pkg/scrape"
+	"github.com/kanakkholwal/go-server/types"
+)
+
+// Job states.
+const (
+	StatusRunning   = "running"
+	StatusCompleted = "completed"
+	StatusCancelled = "cancelled"
+)
+
+// Defaults used by Manager.Start for every job.
+const (
+	Concurrency = 30
+	Delay       = 500 * time.Millisecond
+	Timeout     = 2 * time.Hour
+)
+
+// MaxRunning is the most jobs that run at once; each already sends
+// Concurrency requests to the result portal.
+const MaxRunning = 3
+
+// ErrTooManyJobs is returned by Manager.Start while MaxRunning jobs run.
+var ErrTooManyJobs = errors.New("too many scrape jobs are running")
+
+// Finished jobs are kept for Retention, and only the MaxFinished newest of
+// them, so their results do not pile up in memory.
+const (
+	Retention   = 24 * time.Hour
+	MaxFinished = 20
+)
+
+// Summary is the public view of a job without its results.
+type Summary struct {
+	ID         string               `json:"id"`
+	Status     string               `json:"status"`
+	Cohort     types.CohortSelector `json:"cohort"`
+	Total      int                  `json:"total"`
+	Done       int                  `json:"done"`
+	Found      int                  `json:"found"`
+	Failed     int                  `json:"failed"`
+	Abnormal   int                  `json:"abnormal"`
+	CreatedAt  time.Time            `json:"createdAt"`
+	FinishedAt *time.Time           `json:"finishedAt,omitempty"`
+}
+
+// Job is a background scrape of a cohort whose results are kept in memory
+// until the Manager evicts the finished job.
+type Job struct {
+	mu      sync.RWMutex
+	summary Summary
+	results []scrape.ScrapeResult
+	cancel  context.CancelFunc
+}
+
+func (j *Job) Summary() Summary {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.summary
+}
+
+// Results returns a copy of the results collected so far.
+func (j *Job) Results() []scrape.ScrapeResult {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return append([]scrape.ScrapeResult(nil), j.results...)
+}
+
+// Students returns the successfully scraped students collected so far.
+func (j *Job) Students() []types.StudentHtmlParsed {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	students := make([]types.StudentHtmlParsed, 0, j.summary.Found)
+	for _, res := range j.results {
+		if res.Data != nil {
+			students = append(students, *res.Data)
+		}
+	}
+	return students
+}
+
+// Cancel stops a running job; results collected so far are kept.
+func (j *Job) Cancel() {
+	j.cancel()
+}
+
+func (j *Job) record(res scrape.ScrapeResult) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.results = append(j.results, res)
+	j.summary.Done++
+	switch {
+	case res.Data == nil:
+		j.summary.Failed++
+	default:
+		j.summary.Found++
+		if res.Data.Abnormal {
+			j.summary.Abnormal++
+		}
+	}
+}
+
+func (j *Job) finish(status string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	now := time.Now()
+	j.summary.Status = status
+	j.summary.FinishedAt = &now
+}
+
+// Manager keeps track of the scrape jobs started by this process.
+type Manager struct {
+	mu   sync.RWMutex
+	jobs map[string]*Job
+}
+
+func NewManager() *Manager {
+	return &Manager{jobs: map[string]*Job{}}
+}
+
+// Start begins scraping rollNumbers in the background and returns immediately,
+// or returns ErrTooManyJobs while MaxRunning jobs run.
+func (m *Manager) Start(cohort types.CohortSelector, rollNumbers []string) (*Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.prune(time.Now())
+	running := 0
+	for _, job := range m.jobs {
+		if job.Summary().Status == StatusRunning {
+			running++
+		}
+	}
+	if running >= MaxRunning {
+		return nil, ErrTooManyJobs
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	job := &Job{
+		summary: Summary{
+			ID:        uuid.NewString(),
+			Status:    StatusRunning,
+			Cohort:    cohort,
+			Total:     len(rollNumbers),
+			CreatedAt: time.Now(),
+		},
+		cancel: cancel,
+	}
+	m.jobs[job.summary.ID] = job
+
+	go func() {
+		defer cancel()
+		done := scrape.ScrapeEach(ctx, rollNumbers, Concurrency, Delay, job.record)
+		if done < len(rollNumbers) {
+			job.finish(StatusCancelled)
+			return
+		}
+		job.finish(StatusCompleted)
+	}()
+	return job, nil
+}
+
+func (m *Manager) Get(id string) (*Job, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.prune(time.Now())
+	job, ok := m.jobs[id]
+	return job, ok
+}
+
+// List returns the summaries of all jobs, newest first.
+func (m *Manager) List() []Summary {
+	m.mu.Lock()
+	m.prune(time.Now())
+	summaries := make([]Summary, 0, len(m.jobs))
+	for _, job := range m.jobs {
+		summaries = append(summaries, job.Summary())
+	}
+	m.mu.Unlock()
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].CreatedAt.After(summaries[j].CreatedAt) })
+	return summaries
+}
+
+// prune drops finished jobs older than Retention and all but the MaxFinished
+// most recently finished ones. Running jobs are never dropped. The caller
+// holds m.mu.
+func (m *Manager) prune(now time.Time) {
+	finished := []Summary{}
+	for id, job := range m.jobs {
+		summary := job.Summary()
+		if summary.FinishedAt == nil {
+			continue
+		}
+		if now.Sub(*summary.FinishedAt) > Retention {
+			delete(m.jobs, id)
+			continue
+		}
+		finished = append(finished, summary)
+	}
+	if len(finished) <= MaxFinished {
+		return
+	}
+	sort.Slice(finished, func(i, j int) bool { return finished[i].FinishedAt.After(*finished[j].FinishedAt) })
+	for _, summary := range finished[MaxFinished:] {
+		delete(m.jobs, summary.ID)
+	}
+}
@@ -0,0 +1,271 @@
+// Package openapi builds an OpenAPI 3 document from a table of routes and the
+// Go types they accept and return, and validates requests against it.
+package openapi
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+
+	routes []compiledRoute
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem maps lower case HTTP methods to operations.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string              `json:"operationId"`
+	Summary     string              `json:"summary,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+
+	// bodyRequired lists the top level body fields a request must send;
+	// see Route.BodyRequired.
+	bodyRequired []string
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Schema is the subset of the OpenAPI schema object this package produces.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// Param describes a query or path parameter of a Route.
+type Param struct {
+	Name        string
+	Description string
+	// Type is string (default), integer, number or boolean. Comma separated
+	// lists are documented as strings.
+	Type     string
+	Required bool
+	Enum     []string
+	Minimum  *float64
+	Maximum  *float64
+}
+
+// Route is one documented endpoint. Path uses the router's ":name" syntax;
+// path parameters are documented automatically.
+type Route struct {
+	Method  string
+	Path    string
+	ID      string
+	Summary string
+	Tag     string
+	Query   []Param
+	// Body is a value of the JSON request body type, or nil.
+	Body any
+	// Upload lists the content types of a body that is not JSON, such as a
+	// file; it is documented as binary and never validated.
+	Upload []string
+	// BodyRequired lists the top level fields a request body must contain.
+	// Generated schemas mark every field without omitempty as required, which
+	// describes responses; requests are only held to this list.
+	BodyRequired []string
+	// Response is a value of the JSON response type; nil documents an empty
+	// body. ContentType overrides application/json for file downloads.
+	Response    any
+	ContentType string
+	// Status is the success status code, 200 by default.
+	Status string
+	// Errors maps error status codes to their meaning.
+	Errors map[string]string
+}
+
+// Num returns a pointer to v, for Param.Minimum and Param.Maximum.
+func Num(v float64) *float64 { return &v }
+
+type compiledRoute struct {
+	method  string
+	pattern *regexp.Regexp
+	names   []string
+	op      *Operation
+}
+
+var pathParam = regexp.MustCompile(`:([A-Za-z0-9_]+)`)
+
+// Build generates the document for the routes.
+func Build(info Info, routes []Route) *Document {
+	doc := &Document{
+		OpenAPI:    "3.0.3",
+		Info:       info,
+		Paths:      map[string]*PathItem{},
+		Components: Components{Schemas: map[string]*Schema{}},
+	}
+	gen := &generator{components: doc.Components.Schemas}
+	errorSchema := gen.schemaOf(errorBody{})
+
+	for _, route := range routes {
+		op := &Operation{
+			OperationID:  route.ID,
+			Summary:      route.Summary,
+			Responses:    map[string]Response{},
+			bodyRequired: route.BodyRequired,
+		}
+		if route.Tag != "" {
+			op.Tags = []string{route.Tag}
+		}
+		names := []string{}
+		for _, m := range pathParam.FindAllStringSubmatch(route.Path, -1) {
+			names = append(names, m[1])
+			op.Parameters = append(op.Parameters, Parameter{Name: m[1], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+		for _, p := range route.Query {
+			op.Parameters = append(op.Parameters, Parameter{Name: p.Name, In: "query", Description: p.Description, Required: p.Required, Schema: p.schema()})
+		}
+		if route.Body != nil {
+			op.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{"application/json": {Schema: gen.schemaOf(route.Body)}}}
+		}
+		if len(route.Upload) > 0 {
+			op.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{}}
+			for _, contentType := range route.Upload {
+				op.RequestBody.Content[contentType] = MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+			}
+		}
+
+		status := route.Status
+		if status == "" {
+			status = "200"
+		}
+		success := Response{Description: "OK"}
+		switch {
+		case route.ContentType != "":
+			success.Content = map[string]MediaType{route.ContentType: {Schema: &Schema{Type: "string", Format: "binary"}}}
+		case route.Response != nil:
+			success.Content = map[string]MediaType{"application/json": {Schema: gen.schemaOf(route.Response)}}
+		}
+		op.Responses[status] = success
+		for code, description := range route.Errors {
+			op.Responses[code] = Response{Description: description, Content: map[string]MediaType{"application/json": {Schema: errorSchema}}}
+		}
+
+		path := pathParam.ReplaceAllString(route.Path, "{$1}")
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			doc.Paths[path] = item
+		}
+		(*item)[strings.ToLower(route.Method)] = op
+
+		pattern := "^" + pathParam.ReplaceAllString(regexp.QuoteMeta(route.Path), `([^/]+)`) + "/?$"
+		doc.routes = append(doc.routes, compiledRoute{method: strings.ToUpper(route.Method), pattern: regexp.MustCompile(pattern), names: names, op: op})
+	}
+	// static segments win over parameters, as they do in the router
+	sort.SliceStable(doc.routes, func(i, j int) bool {
+		return len(doc.routes[i].names) < len(doc.routes[j].names)
+	})
+	return doc
+}
+
+// JSON renders the document.
+func (d *Document) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
+
+// Operations lists the operations sorted by path and method, with their path
+// templates, for code generators.
+func (d *Document) Operations() []PathOperation {
+	out := []PathOperation{}
+	for path, item := range d.Paths {
+		for method, op := range *item {
+			out = append(out, PathOperation{Method: strings.ToUpper(method), Path: path, Operation: op})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Path != out[j].Path {
+			return out[i].Path < out[j].Path
+		}
+		return out[i].Method < out[j].Method
+	})
+	return out
+}
+
+type PathOperation struct {
+	Method    string
+	Path      string
+	Operation *Operation
+}
+
+// Find returns the operation serving a request and its path parameters.
+func (d *Document) Find(method, path string) (*Operation, map[string]string, bool) {
+	method = strings.ToUpper(method)
+	for _, route := range d.routes {
+		if route.method != method {
+			continue
+		}
+		m := route.pattern.FindStringSubmatch(path)
+		if m == nil {
+			continue
+		}
+		params := map[string]string{}
+		for i, name := range route.names {
+			params[name] = m[i+1]
+		}
+		return route.op, params, true
+	}
+	return nil, nil, false
+}
+
+func (p Param) schema() *Schema {
+	typ := p.Type
+	if typ == "" {
+		typ = "string"
+	}
+	return &Schema{Type: typ, Enum: p.Enum, Minimum: p.Minimum, Maximum: p.Maximum}
+}
+
+// errorBody is the {"error": "..."} body every route fails with.
+type errorBody struct {
+	Error string `json:"error"`
+}
@@ -0,0 +1,169 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Validate checks a request against its operation: required and typed query
+// parameters, and the JSON body's types and required fields. It returns one
+// message per problem, or nil.
+func (d *Document) Validate(op *Operation, query func(name string) string, contentType string, body []byte) []string {
+	problems := []string{}
+	for _, p := range op.Parameters {
+		if p.In != "query" {
+			continue
+		}
+		raw := query(p.Name)
+		if raw == "" {
+			if p.Required {
+				problems = append(problems, fmt.Sprintf("query parameter %s is required", p.Name))
+			}
+			continue
+		}
+		if msg := checkScalar(p.Schema, raw); msg != "" {
+			problems = append(problems, fmt.Sprintf("query parameter %s %s", p.Name, msg))
+		}
+	}
+
+	if op.RequestBody == nil || !strings.Contains(strings.ToLower(contentType), "json") {
+		return nilIfEmpty(problems)
+	}
+	media, ok := op.RequestBody.Content["application/json"]
+	if !ok || media.Schema.Format == "binary" {
+		return nilIfEmpty(problems)
+	}
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return append(problems, "request body is not valid JSON")
+	}
+	if object, ok := value.(map[string]any); ok {
+		for _, name := range op.bodyRequired {
+			if _, present := object[name]; !present {
+				problems = append(problems, fmt.Sprintf("body field %s is required", name))
+			}
+		}
+	}
+	problems = d.checkValue(media.Schema, value, "body", problems)
+	return nilIfEmpty(problems)
+}
+
+// checkScalar checks a query parameter value against a scalar schema.
+func checkScalar(s *Schema, raw string) string {
+	var number float64
+	switch s.Type {
+	case "integer":
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return "should be an integer"
+		}
+		number = float64(n)
+	case "number":
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return "should be a number"
+		}
+		number = n
+	case "boolean":
+		if _, err := strconv.ParseBool(raw); err != nil {
+			return "should be true or false"
+		}
+		return ""
+	default:
+		if len(s.Enum) > 0 && !slices.Contains(s.Enum, raw) {
+			return "should be one of " + strings.Join(s.Enum, ", ")
+		}
+		return ""
+	}
+	return checkRange(s, number)
+}
+
+func checkRange(s *Schema, number float64) string {
+	if s.Minimum != nil && number < *s.Minimum {
+		return fmt.Sprintf("should be at least %v", *s.Minimum)
+	}
+	if s.Maximum != nil && number > *s.Maximum {
+		return fmt.Sprintf("should be at most %v", *s.Maximum)
+	}
+	return ""
+}
+
+// checkValue checks a decoded JSON value against a schema. Missing fields are
+// not reported; only Operation.bodyRequired is enforced.
+func (d *Document) checkValue(s *Schema, value any, at string, problems []string) []string {
+	if s == nil {
+		return problems
+	}
+	if s.Ref != "" {
+		return d.checkValue(d.Components.Schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")], value, at, problems)
+	}
+	if value == nil {
+		return problems
+	}
+	fail := func(expected string) []string {
+		return append(problems, fmt.Sprintf("%s should be %s", at, expected))
+	}
+	switch s.Type {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return fail("an object")
+		}
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			field := s.AdditionalProperties
+			if prop, ok := s.Properties[key]; ok {
+				field = prop
+			}
+			problems = d.checkValue(field, object[key], at+"."+key, problems)
+		}
+	case "array":
+		list, ok := value.([]any)
+		if !ok {
+			return fail("an array")
+		}
+		for i, item := range list {
+			problems = d.checkValue(s.Items, item, fmt.Sprintf("%s[%d]", at, i), problems)
+		}
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			return fail("a string")
+		}
+		if len(s.Enum) > 0 && !slices.Contains(s.Enum, text) {
+			return fail("one of " + strings.Join(s.Enum, ", "))
+		}
+	case "integer", "number":
+		number, ok := value.(float64)
+		if !ok {
+			return fail("a number")
+		}
+		if s.Type == "integer" && number != math.Trunc(number) {
+			return fail("an integer")
+		}
+		if msg := checkRange(s, number); msg != "" {
+			return append(problems, at+" "+msg)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fail("true or false")
+		}
+	}
+	return problems
+}
+
+func nilIfEmpty(problems []string) []string {
+	if len(problems) == 0 {
+		return nil
+	}
+	return problems
+}
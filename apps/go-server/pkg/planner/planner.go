@@ -0,0 +1,252 @@
+package planner
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/kanakkholwal/go-server/pkg/catalog"
+	"github.com/kanakkholwal/go-server/pkg/grades"
+	"github.com/kanakkholwal/go-server/types"
+)
+
+// PlannedCourse is one course of a hypothetical semester.
+type PlannedCourse struct {
+	Code   string `json:"code,omitempty"`
+	Credit int64  `json:"credit"`
+	Grade  string `json:"grade"`
+}
+
+// FutureSemester is a hypothetical semester, given either as total credits and
+// an expected SGPI or as a list of courses with expected grades.
+type FutureSemester struct {
+	Credits int64           `json:"credits,omitempty"`
+	SGPI    float64         `json:"sgpi,omitempty"`
+	Courses []PlannedCourse `json:"courses,omitempty"`
+}
+
+// GradeReplacement re-grades a course already on the result, e.g. clearing a
+// backlog with a B. Semester limits it to one semester; otherwise the latest
+// attempt of the course is replaced.
+type GradeReplacement struct {
+	Code     string `json:"code"`
+	Grade    string `json:"grade"`
+	Semester string `json:"semester,omitempty"`
+}
+
+type Request struct {
+	RollNumber      string                   `json:"rollNo,omitempty"`
+	Result          *types.StudentHtmlParsed `json:"result,omitempty"`
+	Replacements    []GradeReplacement       `json:"replacements,omitempty"`
+	FutureSemesters []FutureSemester         `json:"futureSemesters,omitempty"`
+	// TargetCGPI asks for the SGPI needed in the semester after any
+	// FutureSemesters, worth NextSemesterCredits credits, to reach it.
+	TargetCGPI          float64 `json:"targetCgpi,omitempty"`
+	NextSemesterCredits int64   `json:"nextSemesterCredits,omitempty"`
+}
+
+// Standing is a credit-weighted position: Points / Credits = CGPI.
+type Standing struct {
+	Credits int64   `json:"credits"`
+	Points  float64 `json:"points"`
+	CGPI    float64 `json:"cgpi"`
+}
+
+type ProjectedSemester struct {
+	Label   string  `json:"label"`
+	Credits int64   `json:"credits"`
+	Points  float64 `json:"points"`
+	SGPI    float64 `json:"sgpi"`
+	CGPI    float64 `json:"cgpi"`
+}
+
+type TargetAnswer struct {
+	TargetCGPI   float64 `json:"targetCgpi"`
+	Credits      int64   `json:"credits"`
+	RequiredSGPI float64 `json:"requiredSgpi"`
+	MaxCGPI      float64 `json:"maxCgpi"`
+	Achievable   bool    `json:"achievable"`
+}
+
+type Plan struct {
+	RollNumber        string              `json:"rollNo"`
+	Phase             string              `json:"phase,omitempty"`
+	ReportedCGPI      float64             `json:"reportedCgpi"`
+	Current           Standing            `json:"current"`
+	AfterReplacements *Standing           `json:"afterReplacements,omitempty"`
+	Projection        []ProjectedSemester `json:"projection"`
+	Projected         Standing            `json:"projected"`
+	Target            *TargetAnswer       `json:"target,omitempty"`
+	Warnings          []string            `json:"warnings,omitempty"`
+}
+
+// Build projects the CGPI of a student under the requested replacements and
+// future semesters. Only the student's latest programme phase is planned, since
+// each phase of a dual degree has its own CGPI. All grades go through the grade
+// scale of the phase's scheme: unknown letters are rejected and grades that do
+// not count towards the CGPI are left out of both points and credits.
+func Build(student *types.StudentHtmlParsed, req Request) (*Plan, error) {
+	if student == nil || len(student.SemesterResults) == 0 {
+		return nil, fmt.Errorf("the result has no semesters to plan from")
+	}
+	last := student.SemesterResults[len(student.SemesterResults)-1]
+	scale := grades.ForScheme(last.Scheme)
+	plan := &Plan{
+		RollNumber:   student.RollNumber,
+		Phase:        last.Phase,
+		ReportedCGPI: last.CGPI,
+		Projection:   []ProjectedSemester{},
+	}
+
+	// copy the semesters of the latest phase so replacements do not touch the input
+	semesters := []types.SemesterResult{}
+	for _, sem := range student.SemesterResults {
+		if sem.Phase != last.Phase {
+			continue
+		}
+		sem.SubjectResults = append([]types.SubjectResult(nil), sem.SubjectResults...)
+		semesters = append(semesters, sem)
+	}
+	plan.Current = standingOf(semesters, scale)
+
+	if len(req.Replacements) > 0 {
+		for _, r := range req.Replacements {
+			if err := replaceGrade(semesters, r, scale); err != nil {
+				return nil, err
+			}
+		}
+		after := standingOf(semesters, scale)
+		plan.AfterReplacements = &after
+	}
+
+	running := plan.Current
+	if plan.AfterReplacements != nil {
+		running = *plan.AfterReplacements
+	}
+	for i, future := range req.FutureSemesters {
+		credits, points, err := futureSemester(future, scale)
+		if err != nil {
+			return nil, fmt.Errorf("future semester %d: %w", i+1, err)
+		}
+		running.Credits += credits
+		running.Points += points
+		running.CGPI = ratio(running.Points, running.Credits)
+		plan.Projection = append(plan.Projection, ProjectedSemester{
+			Label:   fmt.Sprintf("+%d", i+1),
+			Credits: credits,
+			Points:  points,
+			SGPI:    ratio(points, credits),
+			CGPI:    running.CGPI,
+		})
+	}
+	plan.Projected = running
+
+	if req.TargetCGPI > 0 {
+		if req.NextSemesterCredits <= 0 {
+			return nil, fmt.Errorf("nextSemesterCredits is required with targetCgpi")
+		}
+		maxPoint := maxGradePoint(scale)
+		n := float64(req.NextSemesterCredits)
+		total := float64(running.Credits) + n
+		required := (req.TargetCGPI*total - running.Points) / n
+		plan.Target = &TargetAnswer{
+			TargetCGPI:   req.TargetCGPI,
+			Credits:      req.NextSemesterCredits,
+			RequiredSGPI: round2(math.Max(required, 0)),
+			MaxCGPI:      round2((running.Points + maxPoint*n) / total),
+			Achievable:   required <= maxPoint,
+		}
+		if !plan.Target.Achievable {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("a CGPI of %.2f needs an SGPI of %.2f, above the maximum grade point %.0f", req.TargetCGPI, required, maxPoint))
+		}
+	}
+	return plan, nil
+}
+
+func standingOf(semesters []types.SemesterResult, scale *grades.Scale) Standing {
+	var s Standing
+	for _, sem := range semesters {
+		for _, subject := range sem.SubjectResults {
+			if !scale.CountsTowardsCGPI(subject) {
+				continue
+			}
+			point, _ := scale.GradePoint(subject)
+			s.Credits += subject.Credit
+			s.Points += point * float64(subject.Credit)
+		}
+	}
+	s.CGPI = ratio(s.Points, s.Credits)
+	return s
+}
+
+func replaceGrade(semesters []types.SemesterResult, r GradeReplacement, scale *grades.Scale) error {
+	grade, ok := scale.Lookup(r.Grade)
+	if !ok {
+		return fmt.Errorf("grade %q is not on the %s grade scale", r.Grade, scale.Name)
+	}
+	key := catalog.Key(r.Code)
+	for i := len(semesters) - 1; i >= 0; i-- {
+		if r.Semester != "" && semesters[i].SemesterNumber != r.Semester {
+			continue
+		}
+		for j := range semesters[i].SubjectResults {
+			subject := &semesters[i].SubjectResults[j]
+			if catalog.Key(subject.SubjectCode) != key {
+				continue
+			}
+			subject.Grade = grade.Letter
+			subject.Points = int64(math.Round(grade.Point * float64(subject.Credit)))
+			subject.CGPI = grade.Point
+			return nil
+		}
+	}
+	return fmt.Errorf("course %s not found on the result", strings.ToUpper(r.Code))
+}
+
+func futureSemester(f FutureSemester, scale *grades.Scale) (int64, float64, error) {
+	if len(f.Courses) == 0 {
+		if f.Credits <= 0 {
+			return 0, 0, fmt.Errorf("credits or courses are required")
+		}
+		if f.SGPI < 0 || f.SGPI > maxGradePoint(scale) {
+			return 0, 0, fmt.Errorf("sgpi %.2f is outside the grade scale", f.SGPI)
+		}
+		return f.Credits, f.SGPI * float64(f.Credits), nil
+	}
+	var credits int64
+	var points float64
+	for _, course := range f.Courses {
+		subject := types.SubjectResult{SubjectCode: course.Code, Credit: course.Credit, Grade: course.Grade}
+		if _, ok := scale.Lookup(course.Grade); !ok {
+			return 0, 0, fmt.Errorf("grade %q is not on the %s grade scale", course.Grade, scale.Name)
+		}
+		if !scale.CountsTowardsCGPI(subject) {
+			continue
+		}
+		point, _ := scale.GradePoint(subject)
+		credits += course.Credit
+		points += point * float64(course.Credit)
+	}
+	return credits, points, nil
+}
+
+func maxGradePoint(scale *grades.Scale) float64 {
+	best := 0.0
+	for _, g := range scale.Grades {
+		if g.CountsTowardsCGPI && !g.Audit && g.Point > best {
+			best = g.Point
+		}
+	}
+	return best
+}
+
+func ratio(points float64, credits int64) float64 {
+	if credits <= 0 {
+		return 0
+	}
+	return round2(points / float64(credits))
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,111 @@
+// Package refresher runs the refreshes of data scraped from nith.ac.in and
+// kept between restarts, such as the faculty directory and the announcement
+// feed: one refresh at a time, persisted before it is served, and on a
+// schedule with a deadline.
+package refresher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StartupDelay is the least Schedule waits before its first refresh, so a
+// server that restarts, or starts without saved data, does not scrape every
+// page the moment it comes up.
+const StartupDelay = time.Minute
+
+// ErrRefreshing is returned by Run while another refresh is running.
+var ErrRefreshing = errors.New("a refresh is already running")
+
+// Persister keeps the data between restarts; store.Store satisfies it.
+type Persister interface {
+	SaveDocument(name string, v any) error
+	LoadDocument(name string, v any) (bool, error)
+}
+
+// Refresher persists one store document. Name appears in errors and log
+// lines, e.g. "faculty".
+type Refresher struct {
+	Name     string
+	Document string
+	Persist  Persister
+
+	running sync.Mutex
+}
+
+// Load decodes the saved document into v and reports whether there was a
+// readable one. An unreadable document is logged, so the caller starts
+// empty and the next refresh replaces it.
+func (r *Refresher) Load(v any) bool {
+	ok, err := r.Persist.LoadDocument(r.Document, v)
+	if err != nil {
+		log.Printf("%s: %v; starting empty", r.Name, err)
+		return false
+	}
+	return ok
+}
+
+// Save persists v, the result of a refresh, before the caller serves it.
+func (r *Refresher) Save(v any) error {
+	return r.Persist.SaveDocument(r.Document, v)
+}
+
+// Run calls refresh unless another refresh is running, in which case it
+// returns an ErrRefreshing error.
+func (r *Refresher) Run(refresh func() error) error {
+	if !r.running.TryLock() {
+		return fmt.Errorf("%s: %w", r.Name, ErrRefreshing)
+	}
+	defer r.running.Unlock()
+	return refresh()
+}
+
+// Schedule calls refresh every interval until ctx is done, each call bounded
+// by timeout. The first call is due interval after last, the latest refresh,
+// and at least StartupDelay from now. refresh returns a note on a partial
+// failure, or an error; both are logged.
+func (r *Refresher) Schedule(ctx context.Context, interval, timeout time.Duration, last *time.Time, refresh func(ctx context.Context) (string, error)) {
+	if interval <= 0 {
+		return
+	}
+	wait := StartupDelay
+	if last != nil {
+		wait = max(time.Until(last.Add(interval)), StartupDelay)
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+		refreshCtx, cancel := context.WithTimeout(ctx, timeout)
+		note, err := refresh(refreshCtx)
+		cancel()
+		switch {
+		case err != nil:
+			log.Printf("%s refresh: %v", r.Name, err)
+		case note != "":
+			log.Printf("%s refresh: %s", r.Name, note)
+		}
+		timer.Reset(interval)
+	}
+}
+
+// Keys lists the keys of failed, e.g. the departments or pages a refresh
+// could not read, sorted and comma separated.
+func Keys(failed map[string]string) string {
+	keys := make([]string, 0, len(failed))
+	for key := range failed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ", ")
+}
@@ -0,0 +1,277 @@
+package resultcache
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/kanakkholwal/go-server/types"
+)
+
+// Status says how a result was served.
+const (
+	StatusHit   = "HIT"   // fresh enough for the request
+	StatusStale = "STALE" // too old, served while it is refetched in the background
+	StatusMiss  = "MISS"  // fetched from the result portal
+)
+
+// FetchTimeout bounds a fetch from the portal. The fetch is shared by every
+// request waiting for the roll number, so it does not end with the request
+// that started it.
+const FetchTimeout = time.Minute
+
+// Entry is a cached result and when it was fetched.
+type Entry struct {
+	Student   types.StudentHtmlParsed `json:"student"`
+	FetchedAt time.Time               `json:"fetchedAt"`
+	ETag      string                  `json:"etag"`
+}
+
+// Age is the time since the result was fetched, in whole seconds.
+func (e Entry) Age() time.Duration {
+	return time.Since(e.FetchedAt).Truncate(time.Second)
+}
+
+// Options are the freshness requirements of one request, in the sense of the
+// Cache-Control request directives.
+type Options struct {
+	// Fresh skips the cache entirely.
+	Fresh bool
+	// MaxAge is the oldest result the client accepts; zero means the cache TTL.
+	MaxAge time.Duration
+	// StaleWhileRevalidate serves results up to this much older than MaxAge
+	// while a fresh copy is fetched in the background.
+	StaleWhileRevalidate time.Duration
+}
+
+// FetchFunc fetches a result from the portal, giving up when ctx is done.
+type FetchFunc func(ctx context.Context, rollNo string) (*types.StudentHtmlParsed, error)
+
+// Cache keeps fetched results in memory, the most recently used up to a
+// limit, and, when a directory is given, on disk so they survive restarts.
+// Concurrent fetches of the same roll number share one upstream request.
+type Cache struct {
+	ttl        time.Duration
+	maxEntries int
+	dir        string
+	fetch      FetchFunc
+
+	mu sync.Mutex
+	// entries index recent, whose elements hold *item from most to least
+	// recently used
+	entries map[string]*list.Element
+	recent  *list.List
+	// fetching holds the keys the cache is fetching itself; Put leaves them
+	// to refresh, so a result is stored once
+	fetching map[string]bool
+	group    singleflight.Group
+}
+
+type item struct {
+	key   string
+	entry Entry
+}
+
+// New creates a cache whose entries are fresh for ttl and keeps at most
+// maxEntries of them in memory; zero or less means no limit. dir may be
+// empty to keep the cache in memory only.
+func New(ttl time.Duration, maxEntries int, dir string, fetch FetchFunc) (*Cache, error) {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("create result cache directory: %w", err)
+		}
+	}
+	return &Cache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		dir:        dir,
+		fetch:      fetch,
+		entries:    map[string]*list.Element{},
+		recent:     list.New(),
+		fetching:   map[string]bool{},
+	}, nil
+}
+
+// Get returns the result of a roll number, fetching it when the cached copy
+// does not satisfy opts. ctx bounds how long Get waits for a fetch; the
+// fetch itself, like a background revalidation, outlives it.
+func (c *Cache) Get(ctx context.Context, rollNo string, opts Options) (Entry, string, error) {
+	key := cacheKey(rollNo)
+	if !opts.Fresh {
+		maxAge := opts.MaxAge
+		if maxAge <= 0 {
+			maxAge = c.ttl
+		}
+		if entry, ok := c.lookup(key); ok {
+			age := time.Since(entry.FetchedAt)
+			if age <= maxAge {
+				return entry, StatusHit, nil
+			}
+			if age <= maxAge+opts.StaleWhileRevalidate {
+				go func() {
+					if _, err := c.refresh(context.WithoutCancel(ctx), key); err != nil {
+						log.Printf("resultcache: revalidate %s: %v", key, err)
+					}
+				}()
+				return entry, StatusStale, nil
+			}
+		}
+	}
+	entry, err := c.refresh(ctx, key)
+	return entry, StatusMiss, err
+}
+
+// Put stores a result fetched elsewhere, e.g. by a bulk scrape. Results of
+// the cache's own fetches, which may reach Put through a scrape listener, are
+// stored by the fetch instead.
+func (c *Cache) Put(student *types.StudentHtmlParsed) {
+	if student == nil || student.RollNumber == "" {
+		return
+	}
+	key := cacheKey(student.RollNumber)
+	c.mu.Lock()
+	fetching := c.fetching[key]
+	c.mu.Unlock()
+	if fetching {
+		return
+	}
+	c.save(newEntry(*student))
+}
+
+// refresh fetches key once for every concurrent caller. The fetch runs on a
+// context of its own, bounded by FetchTimeout, so a caller that gives up
+// returns early without failing the fetch for the others.
+func (c *Cache) refresh(ctx context.Context, key string) (Entry, error) {
+	ch := c.group.DoChan(key, func() (any, error) {
+		c.mu.Lock()
+		c.fetching[key] = true
+		c.mu.Unlock()
+		defer func() {
+			c.mu.Lock()
+			delete(c.fetching, key)
+			c.mu.Unlock()
+		}()
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), FetchTimeout)
+		defer cancel()
+		student, err := c.fetch(fetchCtx, key)
+		if err != nil {
+			return Entry{}, err
+		}
+		entry := newEntry(*student)
+		c.save(entry)
+		return entry, nil
+	})
+	select {
+	case <-ctx.Done():
+		return Entry{}, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return Entry{}, res.Err
+		}
+		return res.Val.(Entry), nil
+	}
+}
+
+func (c *Cache) lookup(key string) (Entry, bool) {
+	c.mu.Lock()
+	el, ok := c.entries[key]
+	if ok {
+		c.recent.MoveToFront(el)
+	}
+	c.mu.Unlock()
+	if ok {
+		return el.Value.(*item).entry, true
+	}
+	if c.dir == "" {
+		return Entry{}, false
+	}
+	var entry Entry
+	raw, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return Entry{}, false
+	}
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		log.Printf("resultcache: decode %s: %v", key, err)
+		return Entry{}, false
+	}
+	c.remember(key, entry)
+	return entry, true
+}
+
+// remember keeps entry in memory as the most recently used, dropping the
+// least recently used entries beyond maxEntries. Dropped entries stay on
+// disk.
+func (c *Cache) remember(key string, entry Entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		el.Value.(*item).entry = entry
+		c.recent.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.recent.PushFront(&item{key: key, entry: entry})
+	for c.maxEntries > 0 && c.recent.Len() > c.maxEntries {
+		oldest := c.recent.Back()
+		c.recent.Remove(oldest)
+		delete(c.entries, oldest.Value.(*item).key)
+	}
+}
+
+func (c *Cache) save(entry Entry) {
+	key := cacheKey(entry.Student.RollNumber)
+	c.remember(key, entry)
+	if c.dir == "" {
+		return
+	}
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		log.Printf("resultcache: encode %s: %v", key, err)
+		return
+	}
+	// write to a temporary file first so readers never see a partial entry
+	tmp := c.path(key) + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		log.Printf("resultcache: write %s: %v", key, err)
+		return
+	}
+	if err := os.Rename(tmp, c.path(key)); err != nil {
+		log.Printf("resultcache: write %s: %v", key, err)
+	}
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+func newEntry(student types.StudentHtmlParsed) Entry {
+	student.Standing = nil
+	raw, _ := json.Marshal(student)
+	sum := sha256.Sum256(raw)
+	return Entry{
+		Student:   student,
+		FetchedAt: time.Now().UTC().Truncate(time.Second),
+		ETag:      `"` + hex.EncodeToString(sum[:8]) + `"`,
+	}
+}
+
+// cacheKey normalises a roll number; only letters and digits are kept so the
+// key is also safe as a file name.
+func cacheKey(rollNo string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return -1
+	}, strings.ToUpper(strings.TrimSpace(rollNo)))
+}
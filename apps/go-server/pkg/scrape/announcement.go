@@ -0,0 +1,132 @@
+package scrape
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Announcement kinds, one per page of nith.ac.in.
+const (
+	KindNotice = "notice"
+	KindTender = "tender"
+	KindEvent  = "event"
+)
+
+// Announcement is one item of a notice, tender or event page. ID is stable
+// for as long as the item keeps its title and link.
+type Announcement struct {
+	ID      string     `json:"id"`
+	Kind    string     `json:"kind"`
+	Title   string     `json:"title"`
+	URL     string     `json:"url,omitempty"`
+	Date    *time.Time `json:"date,omitempty"`
+	Summary string     `json:"summary,omitempty"`
+}
+
+// announcementFields are read from every announcement page: the title and
+// link of the item's first link, and its date, taken from the last cell of a
+// table row.
+var announcementFields = map[string]Field{
+	"title":   {Selector: "a"},
+	"link":    {Selector: "a", Attr: "href"},
+	"date":    {Selector: "time, .date, .post-date, td:last-child"},
+	"summary": {Selector: "p, .summary"},
+}
+
+// AnnouncementPages are the list pages of nith.ac.in scraped for
+// announcements, named by kind. The pages list items either as table rows or
+// as article blocks.
+var AnnouncementPages = []ListSpec{
+	{
+		Name:     KindNotice,
+		URL:      "https://nith.ac.in/notices",
+		Items:    []string{"#content table tbody tr", "#content article", ".notice-list li"},
+		Fields:   announcementFields,
+		Required: []string{"title"},
+	},
+	{
+		Name:     KindTender,
+		URL:      "https://nith.ac.in/tenders",
+		Items:    []string{"#content table tbody tr", "#content article"},
+		Fields:   announcementFields,
+		Required: []string{"title"},
+	},
+	{
+		Name:     KindEvent,
+		URL:      "https://nith.ac.in/events",
+		Items:    []string{"#content article", "#content .event", "#content table tbody tr"},
+		Fields:   announcementFields,
+		Required: []string{"title"},
+	},
+}
+
+// GetAnnouncements scrapes the announcements of one page of
+// AnnouncementPages.
+func GetAnnouncements(ctx context.Context, spec ListSpec) ([]Announcement, error) {
+	records, err := ScrapeList(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	return announcementsOf(spec.Name, records), nil
+}
+
+// ParseAnnouncements reads the announcements of a saved page of spec.
+func ParseAnnouncements(r io.Reader, spec ListSpec) ([]Announcement, error) {
+	records, err := spec.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	return announcementsOf(spec.Name, records), nil
+}
+
+func announcementsOf(kind string, records []Record) []Announcement {
+	out := make([]Announcement, 0, len(records))
+	seen := map[string]bool{}
+	for _, record := range records {
+		a := Announcement{Kind: kind, Title: record["title"], URL: record["link"], Summary: record["summary"]}
+		if date, ok := parseAnnouncementDate(record["date"]); ok {
+			a.Date = &date
+		}
+		sum := sha1.Sum([]byte(kind + "\n" + a.URL + "\n" + a.Title))
+		a.ID = hex.EncodeToString(sum[:8])
+		// pages repeat pinned items at the top
+		if seen[a.ID] {
+			continue
+		}
+		seen[a.ID] = true
+		out = append(out, a)
+	}
+	return out
+}
+
+var announcementDate = regexp.MustCompile(`\d{4}-\d{2}-\d{2}|\d{1,2}[-/.]\d{1,2}[-/.]\d{4}|\d{1,2}(?:st|nd|rd|th)?\s+[A-Za-z]{3,9},?\s+\d{4}|[A-Za-z]{3,9}\s+\d{1,2},?\s+\d{4}`)
+
+var ordinalSuffix = regexp.MustCompile(`(\d)(?:st|nd|rd|th)`)
+
+var announcementDateLayouts = []string{
+	"2006-01-02", "02-01-2006", "2-1-2006", "02/01/2006", "2/1/2006", "02.01.2006", "2.1.2006",
+	"2 Jan 2006", "2 January 2006", "2 Jan, 2006", "2 January, 2006",
+	"Jan 2 2006", "January 2 2006", "Jan 2, 2006", "January 2, 2006",
+}
+
+// parseAnnouncementDate finds a date in text such as "Posted on 23-08-2024".
+// Numeric dates are day first, as the institute writes them.
+func parseAnnouncementDate(text string) (time.Time, bool) {
+	match := announcementDate.FindString(text)
+	if match == "" {
+		return time.Time{}, false
+	}
+	match = ordinalSuffix.ReplaceAllString(match, "$1")
+	match = strings.Join(strings.Fields(match), " ")
+	for _, layout := range announcementDateLayouts {
+		if t, err := time.Parse(layout, match); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
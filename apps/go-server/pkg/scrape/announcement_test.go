@@ -0,0 +1,95 @@
+package scrape
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAnnouncements(t *testing.T) {
+	type item struct {
+		title, url, date, summary string
+	}
+	tests := []struct {
+		name string
+		file string
+		spec ListSpec
+		want []item
+	}{
+		{
+			name: "notices table",
+			file: "notices.html",
+			spec: AnnouncementPages[0],
+			want: []item{
+				{"Fee deposit schedule for odd semester", "https://nith.ac.in/uploads/notices/fee.pdf", "2024-08-23", ""},
+				{"Holiday on account of Janmashtami", "https://nith.ac.in/uploads/notices/holiday.pdf", "2024-08-26", ""},
+				{"Convocation registration", "https://nith.ac.in/notices/convocation", "", ""},
+			},
+		},
+		{
+			name: "event articles",
+			file: "events.html",
+			spec: AnnouncementPages[2],
+			want: []item{
+				{"Hill Hacks 2024", "https://nith.ac.in/events/hackathon-2024", "2024-09-14", "A 36 hour hackathon organised by the coding club."},
+				{"Nimbus technical festival", "https://nith.ac.in/events/nimbus", "2024-03-22", "Annual technical festival of the institute."},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAnnouncements(openTestdata(t, tt.file), tt.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d announcements, want %d: %+v", len(got), len(tt.want), got)
+			}
+			ids := map[string]bool{}
+			for i, want := range tt.want {
+				a := got[i]
+				date := ""
+				if a.Date != nil {
+					date = a.Date.Format(time.DateOnly)
+				}
+				if a.Title != want.title || a.URL != want.url || date != want.date || a.Summary != want.summary {
+					t.Errorf("announcement %d = %q %q %q %q, want %q %q %q %q", i, a.Title, a.URL, date, a.Summary, want.title, want.url, want.date, want.summary)
+				}
+				if a.Kind != tt.spec.Name {
+					t.Errorf("announcement %d kind = %q, want %q", i, a.Kind, tt.spec.Name)
+				}
+				if a.ID == "" || ids[a.ID] {
+					t.Errorf("announcement %d has an empty or repeated id %q", i, a.ID)
+				}
+				ids[a.ID] = true
+			}
+		})
+	}
+}
+
+func TestParseAnnouncementDate(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"23-08-2024", "2024-08-23"},
+		{"Posted on 2/9/2024", "2024-09-02"},
+		{"05.01.2025", "2025-01-05"},
+		{"2024-12-31", "2024-12-31"},
+		{"1st Jan 2025", "2025-01-01"},
+		{"Last date: March 3, 2025", "2025-03-03"},
+		{"to be announced", ""},
+		{"31-02-2024", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, ok := parseAnnouncementDate(tt.text)
+			date := ""
+			if ok {
+				date = got.Format(time.DateOnly)
+			}
+			if date != tt.want {
+				t.Errorf("parseAnnouncementDate(%q) = %q, want %q", tt.text, date, tt.want)
+			}
+		})
+	}
+}
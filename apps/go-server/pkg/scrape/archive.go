@@ -0,0 +1,87 @@
+package scrape
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+
+	resultTypes "github.com/kanakkholwal/go-server/types"
+	"github.com/kanakkholwal/go-server/utils"
+)
+
+// Page is the raw result page of one roll number as served by one result
+// source, kept so that results can be parsed again without the portal.
+type Page struct {
+	RollNumber string
+	Source     utils.ResultSource
+	HTML       []byte
+}
+
+var (
+	pageListenersMu sync.RWMutex
+	pageListeners   []func(page Page)
+)
+
+// OnResultPage registers fn to be called with every result page fetched by
+// GetResultByRollNumber, before it is parsed, e.g. to archive it. Listeners
+// run synchronously on the scraping goroutine and must not modify the page.
+func OnResultPage(fn func(page Page)) {
+	pageListenersMu.Lock()
+	defer pageListenersMu.Unlock()
+	pageListeners = append(pageListeners, fn)
+}
+
+// notifyPage hands the fetched page to the page listeners. The body is only
+// buffered when someone listens; the returned reader replaces body.
+func notifyPage(rollNumber string, source utils.ResultSource, body io.ReadCloser) (io.ReadCloser, error) {
+	pageListenersMu.RLock()
+	defer pageListenersMu.RUnlock()
+	if len(pageListeners) == 0 {
+		return body, nil
+	}
+	html, err := io.ReadAll(body)
+	body.Close()
+	if err != nil {
+		return nil, err
+	}
+	for _, fn := range pageListeners {
+		fn(Page{RollNumber: rollNumber, Source: source, HTML: html})
+	}
+	return io.NopCloser(bytes.NewReader(html)), nil
+}
+
+// ParsePages rebuilds a result from archived pages the way
+// GetResultByRollNumber builds it from the portal. Pages are matched to the
+// roll number's result sources by scheme; the page of the primary source is
+// required. Listeners are not notified.
+func ParsePages(rollNumber string, pages []Page) (*resultTypes.StudentHtmlParsed, error) {
+	sources := utils.GetResultSources(rollNumber, len(pages) > 1)
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("invalid roll number %s | No result path found", rollNumber)
+	}
+	var student *resultTypes.StudentHtmlParsed
+	for idx, source := range sources {
+		var page *Page
+		for i := range pages {
+			if pages[i].Source.Scheme == source.Scheme {
+				page = &pages[i]
+				break
+			}
+		}
+		if page == nil {
+			if idx == 0 {
+				return nil, fmt.Errorf("no %s page archived for rollNumber %s", source.Scheme, rollNumber)
+			}
+			student.Warnings = append(student.Warnings, fmt.Sprintf("%s phase results not archived from %s", source.Phase, source.Scheme))
+			continue
+		}
+		parsed, err := ParseResultHtml(io.NopCloser(bytes.NewReader(page.HTML)))
+		student, err = mergeSource(student, idx, source, parsed, err)
+		if err != nil {
+			return nil, fmt.Errorf("error for rollNumber %s: %w", rollNumber, err)
+		}
+	}
+	enrichStudent(student)
+	return student, nil
+}
@@ -0,0 +1,120 @@
+package scrape
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/kanakkholwal/go-server/pkg/upstream"
+)
+
+// Client fetches institute pages through the shared upstream client. It
+// retries transient failures and keeps a pause between requests to one host
+// so a scrape does not hammer the college servers.
+type Client struct {
+	// Upstream sends the requests; nil means upstream.Default().
+	Upstream *upstream.Client
+	// Retries is how often a page is fetched again after a network error, a
+	// rate limit or a server error.
+	Retries int
+	// Delay is the least time between the starts of two requests to the
+	// same host.
+	Delay time.Duration
+
+	mu   sync.Mutex
+	next map[string]time.Time
+}
+
+func NewClient(delay time.Duration) *Client {
+	return &Client{Retries: 3, Delay: delay, next: map[string]time.Time{}}
+}
+
+// DefaultClient is shared by the page scrapers of this package.
+var DefaultClient = NewClient(500 * time.Millisecond)
+
+// Get fetches a page, retrying network errors, rate limits and server errors
+// with a growing, jittered pause like the result scraper does. Other failures,
+// such as a 404 or an oversized page, are returned at once.
+func (c *Client) Get(ctx context.Context, pageURL string) ([]byte, error) {
+	var lastErr error
+	for attempt := 0; attempt <= c.Retries; attempt++ {
+		if attempt > 0 {
+			pause := time.Duration(attempt)*time.Second + time.Duration(rand.Intn(1000))*time.Millisecond
+			log.Printf("fetch %s failed (%v), retrying in %s", pageURL, lastErr, pause)
+			select {
+			case <-time.After(pause):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.wait(ctx, req.URL.Host); err != nil {
+			return nil, err
+		}
+		up := c.Upstream
+		if up == nil {
+			up = upstream.Default()
+		}
+		body, err := up.Do(up.HTTPClient(nil), req)
+		switch {
+		case err == nil:
+			return body, nil
+		case ctx.Err() != nil:
+			return nil, ctx.Err()
+		case !upstream.Retryable(err):
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// Document fetches a page and parses it as HTML. The document's Url is the
+// page URL, so relative links can be resolved against it.
+func (c *Client) Document(ctx context.Context, pageURL string) (*goquery.Document, error) {
+	body, err := c.Get(ctx, pageURL)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	doc.Url, _ = url.Parse(pageURL)
+	return doc, nil
+}
+
+// wait blocks until the host may be asked again and books the next slot.
+func (c *Client) wait(ctx context.Context, host string) error {
+	if c.Delay <= 0 {
+		return nil
+	}
+	c.mu.Lock()
+	if c.next == nil {
+		c.next = map[string]time.Time{}
+	}
+	now := time.Now()
+	at := c.next[host]
+	if at.Before(now) {
+		at = now
+	}
+	c.next[host] = at.Add(c.Delay)
+	c.mu.Unlock()
+
+	select {
+	case <-time.After(time.Until(at)):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
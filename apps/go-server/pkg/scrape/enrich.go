@@ -0,0 +1,89 @@
+package scrape
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/kanakkholwal/go-server/pkg/academic"
+	"github.com/kanakkholwal/go-server/pkg/grades"
+	"github.com/kanakkholwal/go-server/pkg/validate"
+	resultTypes "github.com/kanakkholwal/go-server/types"
+	"github.com/kanakkholwal/go-server/utils"
+)
+
+// enrichStudent derives everything computed from the parsed semesters. It runs
+// once per scraped student, after all result sources have been merged.
+func enrichStudent(student *resultTypes.StudentHtmlParsed) {
+	applyGradeScale(student)
+	summarisePhases(student, dualDegreeCGPIRule())
+	status := academic.Analyze(student)
+	student.AcademicStatus = &status
+	// Branch stays the branch of the roll number, which cohort filters match
+	// on; the branch a student moved to is only reported in BranchInfo
+	branch := academic.InferBranch(student)
+	student.BranchInfo = &branch
+	validate.Apply(student)
+}
+
+// Enrich prepares a result that did not come from the portal, such as an
+// imported dump, the same way a scraped one is prepared. Semesters without a
+// phase are attributed to the roll number's primary result source, and every
+// derived field is recomputed. Listeners are not notified.
+func Enrich(student *resultTypes.StudentHtmlParsed) {
+	if sources := utils.GetResultSources(student.RollNumber, false); len(sources) > 0 {
+		for i := range student.SemesterResults {
+			sem := &student.SemesterResults[i]
+			if sem.Phase == "" {
+				sem.Phase = sources[0].Phase
+			}
+			if sem.Scheme == "" {
+				sem.Scheme = sources[0].Scheme
+			}
+		}
+	}
+	student.Warnings = nil
+	student.Standing = nil
+	// older dumps carry the inferred branch in Branch
+	if branch := utils.DetermineDepartment(student.RollNumber); branch != "Unknown" {
+		student.Branch = branch
+	}
+	enrichStudent(student)
+}
+
+// applyGradeScale derives the grade point of every course from the grade scale
+// of the semester's scheme and reports letters the scale does not know.
+func applyGradeScale(student *resultTypes.StudentHtmlParsed) {
+	for i := range student.SemesterResults {
+		sem := &student.SemesterResults[i]
+		scale := grades.ForScheme(sem.Scheme)
+		for j := range sem.SubjectResults {
+			point, warning := scale.GradePoint(sem.SubjectResults[j])
+			sem.SubjectResults[j].CGPI = point
+			if warning != "" {
+				student.Warnings = append(student.Warnings, fmt.Sprintf("semester %s: %s", sem.SemesterNumber, warning))
+			}
+		}
+	}
+}
+
+var (
+	listenersMu sync.RWMutex
+	listeners   []func(student *resultTypes.StudentHtmlParsed)
+)
+
+// OnStudentScraped registers fn to be called with every student scraped and
+// enriched by this package, e.g. to index or persist it. Listeners run
+// synchronously on the scraping goroutine and must not modify the student.
+func OnStudentScraped(fn func(student *resultTypes.StudentHtmlParsed)) {
+	listenersMu.Lock()
+	defer listenersMu.Unlock()
+	listeners = append(listeners, fn)
+}
+
+func notifyScraped(student *resultTypes.StudentHtmlParsed) {
+	listenersMu.RLock()
+	defer listenersMu.RUnlock()
+	for _, fn := range listeners {
+		fn(student)
+	}
+}
@@ -1,11 +1,17 @@
 package scrape
 
 import (
-	"errors"
+	"bytes"
+	"context"
 	"fmt"
-	"net/http"
-	"net/http/cookiejar"
+	"io"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/kanakkholwal/go-server/constants"
 
@@ -13,78 +19,289 @@ import (
 )
 
 type Faculty struct {
-	Name       string `json:"name"`
-	Department string `json:"department"`
-	Email      string `json:"email"`
+	Name          string   `json:"name"`
+	Department    string   `json:"department"`
+	Designation   string   `json:"designation,omitempty"`
+	Email         string   `json:"email"`
+	Phone         string   `json:"phone,omitempty"`
+	ProfileURL    string   `json:"profileUrl,omitempty"`
+	PhotoURL      string   `json:"photoUrl,omitempty"`
+	ResearchAreas []string `json:"researchAreas,omitempty"`
 }
 
-func GetFacultyList() ([]string, error) {
+// DepartmentError is the failure to scrape the faculty of one department.
+type DepartmentError struct {
+	Department string
+	Err        error
+}
 
-	faculties := []Faculty{}
-	// Get the list of departments
+func (e *DepartmentError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Department, e.Err)
+}
+
+func (e *DepartmentError) Unwrap() error { return e.Err }
+
+// DepartmentErrors collects the departments a faculty scrape failed for.
+type DepartmentErrors []*DepartmentError
+
+func (errs DepartmentErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return "faculty scrape failed for " + strings.Join(msgs, "; ")
+}
+
+// facultyConcurrency is how many department pages are fetched at once.
+const facultyConcurrency = 4
 
+// GetFacultyList scrapes the faculty of every department in
+// constants.DepartmentsList. A department that fails does not stop the
+// others: their faculty is returned together with a DepartmentErrors listing
+// the failures. Faculty is ordered by department, then name.
+func GetFacultyList(ctx context.Context) ([]Faculty, error) {
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		faculties = []Faculty{}
+		errs      DepartmentErrors
+	)
+	slots := make(chan struct{}, facultyConcurrency)
 	for _, department := range constants.DepartmentsList {
-		// Get the faculty list for each department
-		facultyList, err := getFacultyListForDepartment(department)
-		if facultyList != nil {
-			faculties = append(faculties, facultyList...)
-		} else {
-			return nil, err
-		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			list, err := GetDepartmentFaculty(ctx, department)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, &DepartmentError{Department: department.Code, Err: err})
+				return
+			}
+			faculties = append(faculties, list...)
+		}()
 	}
+	wg.Wait()
 
-	return nil, errors.New("not implemented")
+	sort.SliceStable(faculties, func(i, j int) bool {
+		if faculties[i].Department != faculties[j].Department {
+			return faculties[i].Department < faculties[j].Department
+		}
+		return faculties[i].Name < faculties[j].Name
+	})
+	if len(errs) > 0 {
+		sort.Slice(errs, func(i, j int) bool { return errs[i].Department < errs[j].Department })
+		return faculties, errs
+	}
+	return faculties, nil
 }
 
-func getFacultyListForDepartment(department constants.Department) ([]Faculty, error) {
-	// Create a cookie jar to store cookies
-	jar, err := cookiejar.New(nil)
+// GetDepartmentFaculty scrapes the faculty listed on a department's page.
+func GetDepartmentFaculty(ctx context.Context, department constants.Department) ([]Faculty, error) {
+	page, err := DefaultClient.Get(ctx, department.Page)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to get faculty list: %w", err)
 	}
+	return ParseFacultyPage(bytes.NewReader(page), department)
+}
 
-	// Create a new HTTP client with the cookie jar
-	client := &http.Client{
-		Jar: jar,
-	}
+// facultyColumn names the fields a faculty table column can hold.
+type facultyColumn int
 
-	// Send a GET request to the department URL
-	resp, err := client.Get(department.Page)
+const (
+	colUnknown facultyColumn = iota
+	colName
+	colDesignation
+	colEmail
+	colPhone
+	colResearch
+)
+
+// defaultFacultyColumns is the layout of the department pages when the table
+// has no recognisable header: photo, name, designation, email, phone.
+var defaultFacultyColumns = []facultyColumn{colUnknown, colName, colDesignation, colEmail, colPhone}
+
+// ParseFacultyPage extracts the faculty table of a department page. The
+// faculty tab (".departmentTab#138") is preferred; otherwise every table
+// with an email column is read. Columns are matched by their header text,
+// falling back to the usual layout of the department pages. Rows without a
+// name or a valid email are skipped.
+func ParseFacultyPage(r io.Reader, department constants.Department) ([]Faculty, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	base, _ := url.Parse(department.Page)
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get faculty list: %s", resp.Status)
+	tables := doc.Find(".departmentTab#138 table")
+	if tables.Length() == 0 {
+		tables = doc.Find("table").FilterFunction(func(_ int, table *goquery.Selection) bool {
+			return strings.Contains(strings.ToLower(table.Text()), "email")
+		})
 	}
-
-	// Parse the HTML response using goquery
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
-	if err != nil {
-		return nil, err
+	if tables.Length() == 0 {
+		return nil, fmt.Errorf("no faculty table found on %s", department.Page)
 	}
 
 	faculties := []Faculty{}
+	seen := map[string]bool{}
+	tables.Each(func(_ int, table *goquery.Selection) {
+		columns := defaultFacultyColumns
+		table.Find("tr").Each(func(_ int, row *goquery.Selection) {
+			cells := row.Find("th, td")
+			if header := facultyHeader(cells); header != nil {
+				columns = header
+				return
+			}
+			faculty, ok := parseFacultyRow(cells, columns, base)
+			if !ok || seen[faculty.Email] {
+				return
+			}
+			seen[faculty.Email] = true
+			faculty.Department = department.Code
+			faculties = append(faculties, faculty)
+		})
+	})
+	return faculties, nil
+}
 
-	section := doc.Find(".departmentTab#138")
-	section.Find("tr").Each(func(i int, s *goquery.Selection) {
-		if s.Length() < 5 {
-			return
+// facultyHeader maps the cells of a header row to columns by position, or
+// returns nil when the row is not a header. A header names at least two known
+// columns and, unlike every faculty row, holds no email address.
+func facultyHeader(cells *goquery.Selection) []facultyColumn {
+	columns := []facultyColumn{}
+	found, hasEmail := 0, false
+	cells.Each(func(_ int, cell *goquery.Selection) {
+		span := cellSpan(cell)
+		column := colUnknown
+		text := strings.ToLower(strings.TrimSpace(cell.Text()))
+		if strings.Contains(text, "@") || emailAt.MatchString(text) {
+			hasEmail = true
+		}
+		switch {
+		case span > 1:
+			// a cell spanning columns names none of them
+		case text == "name" || strings.HasPrefix(text, "name of") || strings.HasSuffix(text, " name"):
+			column = colName
+		case strings.Contains(text, "designation"):
+			column = colDesignation
+		case strings.Contains(text, "email") || strings.Contains(text, "e-mail"):
+			column = colEmail
+		case strings.Contains(text, "phone") || strings.Contains(text, "contact") || strings.Contains(text, "mobile"):
+			column = colPhone
+		case strings.Contains(text, "research") || strings.Contains(text, "specializ") || strings.Contains(text, "specialis") || strings.Contains(text, "interest"):
+			column = colResearch
 		}
+		if column != colUnknown {
+			found++
+		}
+		columns = append(columns, column)
+		for ; span > 1; span-- {
+			columns = append(columns, colUnknown)
+		}
+	})
+	if hasEmail || found < 2 {
+		return nil
+	}
+	return columns
+}
+
+// cellSpan is the number of columns a cell covers, from its colspan.
+func cellSpan(cell *goquery.Selection) int {
+	span, err := strconv.Atoi(strings.TrimSpace(cell.AttrOr("colspan", "1")))
+	if err != nil || span < 1 {
+		return 1
+	}
+	return span
+}
 
-		name := s.Nodes[0].FirstChild.Data
-		email := strings.Split(s.Nodes[3].Data, "")[0]
-		if email == "" || name == "" {
+// parseFacultyRow reads the cells of a row into the columns at their
+// positions. A cell spanning several columns, such as a divider naming the
+// next group of faculty, belongs to none of them.
+func parseFacultyRow(cells *goquery.Selection, columns []facultyColumn, base *url.URL) (Faculty, bool) {
+	var faculty Faculty
+	position := 0
+	cells.Each(func(_ int, cell *goquery.Selection) {
+		i, span := position, cellSpan(cell)
+		position += span
+		if span > 1 || i >= len(columns) {
 			return
 		}
-		faculty := Faculty{
-			Name:       strings.TrimSpace(name),
-			Department: department.Code,
-			Email:      strings.TrimSpace(email),
+		text := strings.Join(strings.Fields(cell.Text()), " ")
+		switch columns[i] {
+		case colName:
+			faculty.Name = text
+		case colDesignation:
+			faculty.Designation = text
+		case colEmail:
+			if href, ok := cell.Find("a[href^='mailto:']").Attr("href"); ok {
+				text = strings.TrimPrefix(href, "mailto:")
+			}
+			faculty.Email = normalizeEmail(text)
+		case colPhone:
+			faculty.Phone = text
+		case colResearch:
+			faculty.ResearchAreas = splitResearchAreas(text)
 		}
-		faculties = append(faculties, faculty)
 	})
+	if faculty.Name == "" || faculty.Email == "" {
+		return faculty, false
+	}
+	if _, err := mail.ParseAddress(faculty.Email); err != nil {
+		return faculty, false
+	}
 
-	return faculties, nil
+	// the photo and the profile link may sit in any cell of the row
+	if src, ok := cells.Find("img[src]").First().Attr("src"); ok {
+		faculty.PhotoURL = resolveURL(base, src)
+	}
+	cells.Find("a[href]").EachWithBreak(func(_ int, link *goquery.Selection) bool {
+		href, _ := link.Attr("href")
+		href = strings.TrimSpace(href)
+		if href == "" || href == "#" || strings.HasPrefix(href, "mailto:") || strings.HasPrefix(href, "tel:") {
+			return true
+		}
+		faculty.ProfileURL = resolveURL(base, href)
+		return false
+	})
+	return faculty, true
+}
+
+var (
+	emailAt  = regexp.MustCompile(`\s*[\[(]\s*at\s*[\])]\s*`)
+	emailDot = regexp.MustCompile(`\s*[\[(]\s*dot\s*[\])]\s*`)
+)
+
+// normalizeEmail undoes the "name[at]nith[dot]ac[dot]in" spelling used
+// against address harvesters and keeps the first address of a cell.
+func normalizeEmail(raw string) string {
+	email := emailAt.ReplaceAllString(strings.ToLower(raw), "@")
+	email = emailDot.ReplaceAllString(email, ".")
+	if fields := strings.FieldsFunc(email, func(r rune) bool { return r == ',' || r == ';' || r == '/' || r == ' ' }); len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
+}
+
+func splitResearchAreas(raw string) []string {
+	areas := []string{}
+	for _, area := range strings.FieldsFunc(raw, func(r rune) bool { return r == ',' || r == ';' || r == '\n' }) {
+		if area = strings.TrimSpace(area); area != "" {
+			areas = append(areas, area)
+		}
+	}
+	if len(areas) == 0 {
+		return nil
+	}
+	return areas
+}
+
+func resolveURL(base *url.URL, ref string) string {
+	u, err := url.Parse(strings.TrimSpace(ref))
+	if err != nil || base == nil {
+		return ref
+	}
+	return base.ResolveReference(u).String()
 }
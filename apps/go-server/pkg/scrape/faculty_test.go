@@ -0,0 +1,86 @@
+package scrape
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/kanakkholwal/go-server/constants"
+)
+
+func documentOf(t *testing.T, html string) *goquery.Document {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestParseFacultyPage(t *testing.T) {
+	department := constants.Department{Code: "cse", Page: "https://nith.ac.in/computer-science-engineering"}
+	tests := []struct {
+		name string
+		file string
+		want []Faculty
+	}{
+		{
+			name: "faculty tab with header",
+			file: "faculty.html",
+			want: []Faculty{
+				{
+					Name:          "Dr. Priya Sharma",
+					Department:    "cse",
+					Designation:   "Professor",
+					Email:         "priya@nith.ac.in",
+					Phone:         "01972-254401",
+					ProfileURL:    "https://nith.ac.in/people/priya-sharma",
+					PhotoURL:      "https://nith.ac.in/uploads/faculty/priya.jpg",
+					ResearchAreas: []string{"Machine Learning", "Computer Vision", "Data Mining"},
+				},
+				{
+					Name:        "Dr. Arun Verma",
+					Department:  "cse",
+					Designation: "Assistant Professor",
+					Email:       "arun@nith.ac.in",
+					Phone:       "01972-254402",
+					PhotoURL:    "https://cdn.nith.ac.in/faculty/arun.png",
+				},
+			},
+		},
+		{
+			name: "table without header",
+			file: "faculty_plain.html",
+			want: []Faculty{
+				{
+					Name:        "Dr. Neha Gupta",
+					Department:  "cse",
+					Designation: "Associate Professor",
+					Email:       "neha@nith.ac.in",
+					Phone:       "01972-254501",
+					PhotoURL:    "https://nith.ac.in/images/neha.jpg",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFacultyPage(openTestdata(t, tt.file), department)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("faculty = %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFacultyPageWithoutTable(t *testing.T) {
+	department := constants.Department{Code: "cse", Page: "https://nith.ac.in/computer-science-engineering"}
+	_, err := ParseFacultyPage(strings.NewReader(`<table><tr><td>Home</td></tr></table>`), department)
+	if err == nil {
+		t.Fatal("expected an error for a page without a faculty table")
+	}
+}
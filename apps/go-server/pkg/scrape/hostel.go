@@ -0,0 +1,204 @@
+package scrape
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// HostelsPageURL lists the hostel in-charges and every hostel's functionaries.
+const HostelsPageURL = "https://nith.ac.in/hostel-management"
+
+// Hostel genders, as the Node server reports them.
+const (
+	HostelMale   = "male"
+	HostelFemale = "female"
+	HostelGuest  = "guest_hostel"
+)
+
+// Functionary is one person responsible for a hostel, or for all hostels when
+// Hostel is empty. Role is the designation in snake case, e.g. "warden" or
+// "assistant_warden".
+type Functionary struct {
+	Hostel      string   `json:"hostel,omitempty"`
+	Gender      string   `json:"gender,omitempty"`
+	Role        string   `json:"role"`
+	Name        string   `json:"name"`
+	Phone       string   `json:"phone,omitempty"`
+	Email       string   `json:"email,omitempty"`
+	OtherEmails []string `json:"otherEmails,omitempty"`
+}
+
+type Hostel struct {
+	Name   string `json:"name"`
+	Slug   string `json:"slug"`
+	Gender string `json:"gender"`
+	// Warden is also listed among Functionaries.
+	Warden        *Functionary  `json:"warden,omitempty"`
+	Functionaries []Functionary `json:"functionaries"`
+}
+
+// HostelDirectory is everything on the hostel management page. Skipped
+// describes rows that could not be read; the rest of the page is still used.
+type HostelDirectory struct {
+	InCharges []Functionary `json:"inCharges"`
+	Hostels   []Hostel      `json:"hostels"`
+	Skipped   []string      `json:"skipped,omitempty"`
+}
+
+// GetHostels scrapes the hostel management page.
+func GetHostels(ctx context.Context) (*HostelDirectory, error) {
+	page, err := DefaultClient.Get(ctx, HostelsPageURL)
+	if err != nil {
+		return nil, err
+	}
+	return ParseHostelPage(bytes.NewReader(page))
+}
+
+// ParseHostelPage reads the hostel management page. Its content holds two
+// tables: the in-charges of all hostels, then every hostel as a highlighted
+// ("info") name row followed by one row per functionary. Functionary rows are
+// serial, name, designation, phone and email.
+func ParseHostelPage(r io.Reader) (*HostelDirectory, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+	content := doc.Find("#content")
+	if content.Length() == 0 {
+		content = doc.Selection
+	}
+	tables := content.Find("table")
+	if tables.Length() < 2 {
+		return nil, fmt.Errorf("hostel page has %d tables, expected the in-charge and hostel tables: %w", tables.Length(), InvalidHtml)
+	}
+
+	dir := &HostelDirectory{InCharges: []Functionary{}, Hostels: []Hostel{}}
+	tables.Eq(0).Find("tr").Each(func(_ int, row *goquery.Selection) {
+		f, ok, problem := parseFunctionaryRow(row)
+		if problem != "" {
+			dir.Skipped = append(dir.Skipped, "in-charges: "+problem)
+		}
+		if ok {
+			dir.InCharges = append(dir.InCharges, f)
+		}
+	})
+
+	var current *Hostel
+	tables.Eq(1).Find("tr").Each(func(_ int, row *goquery.Selection) {
+		if row.HasClass("thcolor") {
+			return
+		}
+		if row.HasClass("info") {
+			name := cellText(row.Find("td").First())
+			if name == "" {
+				return
+			}
+			dir.Hostels = append(dir.Hostels, Hostel{Name: name, Slug: hostelSlug(name), Gender: hostelGender(name), Functionaries: []Functionary{}})
+			current = &dir.Hostels[len(dir.Hostels)-1]
+			return
+		}
+		f, ok, problem := parseFunctionaryRow(row)
+		if current == nil {
+			if ok {
+				dir.Skipped = append(dir.Skipped, fmt.Sprintf("hostels: %s listed before any hostel", f.Name))
+			}
+			return
+		}
+		if problem != "" {
+			dir.Skipped = append(dir.Skipped, current.Name+": "+problem)
+		}
+		if !ok {
+			return
+		}
+		f.Hostel, f.Gender = current.Name, current.Gender
+		current.Functionaries = append(current.Functionaries, f)
+		if f.Role == "warden" && current.Warden == nil {
+			warden := f
+			current.Warden = &warden
+		}
+	})
+	return dir, nil
+}
+
+// parseFunctionaryRow reads a serial, name, designation, phone, email row.
+// Rows with fewer cells, such as headers, are not functionaries and are
+// ignored silently; a functionary without a name, designation or valid email
+// is reported as a problem.
+func parseFunctionaryRow(row *goquery.Selection) (Functionary, bool, string) {
+	cells := row.Find("td")
+	if cells.Length() < 5 {
+		return Functionary{}, false, ""
+	}
+	f := Functionary{
+		Name:  cellText(cells.Eq(1)),
+		Role:  hostelRole(cellText(cells.Eq(2))),
+		Phone: cellText(cells.Eq(3)),
+	}
+	emails := cellEmails(cells.Eq(4))
+	if f.Name == "" && f.Role == "" {
+		return f, false, ""
+	}
+	if f.Name == "" || f.Role == "" {
+		return f, false, fmt.Sprintf("row %q has no name or designation", strings.TrimSpace(cellText(row)))
+	}
+	if f.Role == "designation" || strings.EqualFold(f.Name, "name") {
+		// a header row written with td cells
+		return f, false, ""
+	}
+	if len(emails) == 0 {
+		return f, false, fmt.Sprintf("%s (%s) has no valid email", f.Name, f.Role)
+	}
+	f.Email = emails[0]
+	if len(emails) > 1 {
+		f.OtherEmails = emails[1:]
+	}
+	return f, true, ""
+}
+
+func cellText(s *goquery.Selection) string {
+	return strings.Join(strings.Fields(s.Text()), " ")
+}
+
+var emailPattern = regexp.MustCompile(`[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,}`)
+
+// cellEmails returns every valid address in a cell, which may hold several on
+// separate lines or spell them as name[at]nith[dot]ac[dot]in.
+func cellEmails(cell *goquery.Selection) []string {
+	cell.Find("br").ReplaceWithHtml("\n")
+	text := strings.ToLower(cell.Text())
+	text = emailAt.ReplaceAllString(text, "@")
+	text = emailDot.ReplaceAllString(text, ".")
+	emails := []string{}
+	for _, candidate := range emailPattern.FindAllString(text, -1) {
+		if _, err := mail.ParseAddress(candidate); err == nil {
+			emails = append(emails, candidate)
+		}
+	}
+	return emails
+}
+
+func hostelRole(designation string) string {
+	return strings.Join(strings.Fields(strings.ToLower(designation)), "_")
+}
+
+func hostelSlug(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), "-")
+}
+
+func hostelGender(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.Contains(lower, "boy"):
+		return HostelMale
+	case strings.Contains(lower, "girl"):
+		return HostelFemale
+	}
+	return HostelGuest
+}
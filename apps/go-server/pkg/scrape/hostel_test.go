@@ -0,0 +1,153 @@
+package scrape
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func openTestdata(t *testing.T, name string) *os.File {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func TestParseHostelPage(t *testing.T) {
+	dir, err := ParseHostelPage(openTestdata(t, "hostel.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantInCharges := []Functionary{
+		{Role: "chief_warden", Name: "Dr. Anil Kumar", Phone: "01972-254001", Email: "chiefwarden@nith.ac.in", OtherEmails: []string{"anil@nith.ac.in"}},
+		{Role: "hostel_superintendent", Name: "Sh. Ramesh Chand", Phone: "01972-254002", Email: "ramesh@nith.ac.in"},
+	}
+	if !reflect.DeepEqual(dir.InCharges, wantInCharges) {
+		t.Errorf("in-charges = %+v, want %+v", dir.InCharges, wantInCharges)
+	}
+
+	tests := []struct {
+		name          string
+		slug          string
+		gender        string
+		warden        string
+		functionaries []string
+	}{
+		{"Kailash Boys Hostel", "kailash-boys-hostel", HostelMale, "Dr. Rajesh Sharma", []string{"Dr. Rajesh Sharma", "Dr. Vikas Thakur"}},
+		{"Parvati Girls Hostel", "parvati-girls-hostel", HostelFemale, "Dr. Meena Rana", []string{"Dr. Meena Rana"}},
+		{"Institute Guest House", "institute-guest-house", HostelGuest, "", []string{"Sh. Kamal Dev"}},
+	}
+	if len(dir.Hostels) != len(tests) {
+		t.Fatalf("got %d hostels, want %d", len(dir.Hostels), len(tests))
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hostel := dir.Hostels[i]
+			if hostel.Name != tt.name || hostel.Slug != tt.slug || hostel.Gender != tt.gender {
+				t.Errorf("hostel = %q %q %q, want %q %q %q", hostel.Name, hostel.Slug, hostel.Gender, tt.name, tt.slug, tt.gender)
+			}
+			warden := ""
+			if hostel.Warden != nil {
+				warden = hostel.Warden.Name
+			}
+			if warden != tt.warden {
+				t.Errorf("warden = %q, want %q", warden, tt.warden)
+			}
+			names := []string{}
+			for _, f := range hostel.Functionaries {
+				names = append(names, f.Name)
+				if f.Hostel != tt.name || f.Gender != tt.gender {
+					t.Errorf("%s belongs to %q (%s), want %q (%s)", f.Name, f.Hostel, f.Gender, tt.name, tt.gender)
+				}
+			}
+			if !reflect.DeepEqual(names, tt.functionaries) {
+				t.Errorf("functionaries = %q, want %q", names, tt.functionaries)
+			}
+		})
+	}
+
+	wantSkipped := []string{
+		"in-charges: Dr. Sunita Devi (deputy_chief_warden) has no valid email",
+		"hostels: Sh. Early Bird listed before any hostel",
+		"Parvati Girls Hostel: row ",
+	}
+	if len(dir.Skipped) != len(wantSkipped) {
+		t.Fatalf("skipped = %q, want %d entries", dir.Skipped, len(wantSkipped))
+	}
+	for i, prefix := range wantSkipped {
+		if !strings.HasPrefix(dir.Skipped[i], prefix) {
+			t.Errorf("skipped[%d] = %q, want prefix %q", i, dir.Skipped[i], prefix)
+		}
+	}
+}
+
+func TestParseHostelPageInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+	}{
+		{"no tables", `<div id="content"><p>Page under maintenance</p></div>`},
+		{"one table", `<div id="content"><table><tr><td>1</td><td>A</td><td>Warden</td><td>1</td><td>a@nith.ac.in</td></tr></table></div>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseHostelPage(strings.NewReader(tt.html))
+			if !errors.Is(err, InvalidHtml) {
+				t.Errorf("err = %v, want InvalidHtml", err)
+			}
+		})
+	}
+}
+
+func TestParseFunctionaryRow(t *testing.T) {
+	tests := []struct {
+		name    string
+		cells   string
+		want    Functionary
+		ok      bool
+		problem string
+	}{
+		{
+			name:  "complete",
+			cells: `<td>1</td><td>Dr. A</td><td>Assistant  Warden</td><td>123</td><td>a[at]nith[dot]ac[dot]in</td>`,
+			want:  Functionary{Name: "Dr. A", Role: "assistant_warden", Phone: "123", Email: "a@nith.ac.in"},
+			ok:    true,
+		},
+		{
+			name:  "four cells",
+			cells: `<td>1</td><td>Dr. A</td><td>Warden</td><td>123</td>`,
+		},
+		{
+			name:  "header written with td",
+			cells: `<td>S.No.</td><td>Name</td><td>Designation</td><td>Phone</td><td>Email</td>`,
+		},
+		{
+			name:  "empty",
+			cells: `<td></td><td></td><td></td><td></td><td></td>`,
+		},
+		{
+			name:    "no valid email",
+			cells:   `<td>1</td><td>Dr. A</td><td>Warden</td><td>123</td><td>on leave</td>`,
+			problem: "Dr. A (warden) has no valid email",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row := documentOf(t, "<table><tr>"+tt.cells+"</tr></table>").Find("tr")
+			got, ok, problem := parseFunctionaryRow(row)
+			if ok != tt.ok || problem != tt.problem {
+				t.Fatalf("ok, problem = %v, %q, want %v, %q", ok, problem, tt.ok, tt.problem)
+			}
+			if ok && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("functionary = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package scrape
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Field picks one value out of a list item: the text of the first element
+// matching Selector, or its Attr attribute. An empty Selector means the item
+// itself. href and src attributes are resolved against the page URL.
+type Field struct {
+	Selector string `json:"selector,omitempty"`
+	Attr     string `json:"attr,omitempty"`
+}
+
+// ListSpec declares how to read a page that lists similar items, such as
+// notices or tenders, so a new page type needs selectors rather than code.
+type ListSpec struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	// Items are selectors of one item, tried in order until one matches;
+	// pages that were redesigned can keep their old selector as a fallback.
+	Items  []string         `json:"items"`
+	Fields map[string]Field `json:"fields"`
+	// Required fields must not be empty; items missing one are skipped.
+	Required []string `json:"required,omitempty"`
+}
+
+// Record is one item of a list page, keyed by ListSpec.Fields.
+type Record map[string]string
+
+// ScrapeList fetches the page of spec with DefaultClient and reads its items.
+func ScrapeList(ctx context.Context, spec ListSpec) ([]Record, error) {
+	doc, err := DefaultClient.Document(ctx, spec.URL)
+	if err != nil {
+		return nil, err
+	}
+	return spec.Extract(doc)
+}
+
+// Parse reads the items of a saved page of spec.
+func (spec ListSpec) Parse(r io.Reader) ([]Record, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+	doc.Url, _ = url.Parse(spec.URL)
+	return spec.Extract(doc)
+}
+
+// Extract reads the items of a parsed page of spec, in page order. A page
+// where no item selector matches is an InvalidHtml error rather than an empty
+// list, as it usually means the page was redesigned.
+func (spec ListSpec) Extract(doc *goquery.Document) ([]Record, error) {
+	var items *goquery.Selection
+	for _, selector := range spec.Items {
+		if found := doc.Find(selector); found.Length() > 0 {
+			items = found
+			break
+		}
+	}
+	if items == nil {
+		return nil, fmt.Errorf("%s: no items match %s: %w", spec.Name, strings.Join(spec.Items, " or "), InvalidHtml)
+	}
+	records := []Record{}
+	items.Each(func(_ int, item *goquery.Selection) {
+		record := make(Record, len(spec.Fields))
+		for name, field := range spec.Fields {
+			record[name] = field.value(item, doc.Url)
+		}
+		for _, name := range spec.Required {
+			if record[name] == "" {
+				return
+			}
+		}
+		records = append(records, record)
+	})
+	return records, nil
+}
+
+func (f Field) value(item *goquery.Selection, base *url.URL) string {
+	sel := item
+	if f.Selector != "" {
+		sel = item.Find(f.Selector).First()
+	}
+	if f.Attr == "" {
+		return strings.Join(strings.Fields(sel.Text()), " ")
+	}
+	value, ok := sel.Attr(f.Attr)
+	if !ok {
+		return ""
+	}
+	value = strings.TrimSpace(value)
+	if value != "" && (f.Attr == "href" || f.Attr == "src") {
+		value = resolveURL(base, value)
+	}
+	return value
+}
+
+// Validate reports a spec that cannot work, before any page is fetched.
+func (spec ListSpec) Validate() error {
+	if spec.Name == "" || spec.URL == "" {
+		return fmt.Errorf("list spec needs a name and a URL")
+	}
+	if len(spec.Items) == 0 {
+		return fmt.Errorf("list spec %s has no item selector", spec.Name)
+	}
+	for _, name := range spec.Required {
+		if _, ok := spec.Fields[name]; !ok {
+			return fmt.Errorf("list spec %s requires field %q it does not define", spec.Name, name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package scrape
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestListSpecParse(t *testing.T) {
+	tenders := ListSpec{
+		Name:     "tender",
+		URL:      "https://nith.ac.in/tenders",
+		Items:    []string{"#content .tender", "#content li"},
+		Fields:   map[string]Field{"title": {}, "link": {Selector: "a", Attr: "href"}, "id": {Attr: "data-id"}},
+		Required: []string{"title"},
+	}
+	tests := []struct {
+		name    string
+		spec    ListSpec
+		html    string
+		want    []Record
+		invalid bool
+	}{
+		{
+			name: "first selector",
+			spec: tenders,
+			html: `<div id="content"><div class="tender" data-id="7"> Supply of <a href="t/7.pdf">lab  equipment</a> </div><li>ignored</li></div>`,
+			want: []Record{{"title": "Supply of lab equipment", "link": "https://nith.ac.in/t/7.pdf", "id": "7"}},
+		},
+		{
+			name: "fallback selector",
+			spec: tenders,
+			html: `<div id="content"><ul><li><a href="/t/8.pdf">Canteen contract</a></li><li></li></ul></div>`,
+			want: []Record{{"title": "Canteen contract", "link": "https://nith.ac.in/t/8.pdf", "id": ""}},
+		},
+		{
+			name: "items missing a required field",
+			spec: tenders,
+			html: `<div id="content"><ul><li> </li><li><a href="/t/9.pdf"></a></li></ul></div>`,
+			want: []Record{},
+		},
+		{
+			name:    "no selector matches",
+			spec:    tenders,
+			html:    `<div id="main"><p>Redesigned</p></div>`,
+			invalid: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.spec.Parse(strings.NewReader(tt.html))
+			if tt.invalid {
+				if !errors.Is(err, InvalidHtml) {
+					t.Fatalf("err = %v, want InvalidHtml", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("records = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListSpecParseAnnouncementPages(t *testing.T) {
+	got, err := AnnouncementPages[0].Parse(openTestdata(t, "notices.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Record{
+		{"title": "Fee deposit schedule for odd semester", "link": "https://nith.ac.in/uploads/notices/fee.pdf", "date": "23-08-2024", "summary": ""},
+		{"title": "Holiday on account of Janmashtami", "link": "https://nith.ac.in/uploads/notices/holiday.pdf", "date": "Posted on 26th August, 2024", "summary": ""},
+		{"title": "Fee deposit schedule for odd semester", "link": "https://nith.ac.in/uploads/notices/fee.pdf", "date": "23-08-2024", "summary": ""},
+		{"title": "Convocation registration", "link": "https://nith.ac.in/notices/convocation", "date": "to be announced", "summary": ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("records = %v\nwant %v", got, want)
+	}
+}
+
+func TestListSpecValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		spec  ListSpec
+		valid bool
+	}{
+		{"announcement page", AnnouncementPages[0], true},
+		{"no name", ListSpec{URL: "https://nith.ac.in/x", Items: []string{"li"}}, false},
+		{"no url", ListSpec{Name: "x", Items: []string{"li"}}, false},
+		{"no items", ListSpec{Name: "x", URL: "https://nith.ac.in/x"}, false},
+		{"undefined required field", ListSpec{Name: "x", URL: "https://nith.ac.in/x", Items: []string{"li"}, Required: []string{"title"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.spec.Validate(); (err == nil) != tt.valid {
+				t.Errorf("Validate() = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package scrape
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/kanakkholwal/go-server/config"
+	resultTypes "github.com/kanakkholwal/go-server/types"
+	"github.com/kanakkholwal/go-server/utils"
+)
+
+// Rules for deriving the overall CGPI of a student with more than one phase.
+const (
+	CGPIRuleCreditWeighted = "credit_weighted"
+	CGPIRuleLatest         = "latest"
+	CGPIRuleBachelor       = "bachelor"
+	CGPIRuleMax            = "max"
+)
+
+// tagSemesters marks every semester of a parsed page with the phase and scheme
+// of the portal it came from. Semester numbers continue after offset so that a
+// master phase follows the bachelor phase instead of restarting at 1.
+func tagSemesters(student *resultTypes.StudentHtmlParsed, source utils.ResultSource, offset int) {
+	for i := range student.SemesterResults {
+		student.SemesterResults[i].Phase = source.Phase
+		student.SemesterResults[i].Scheme = source.Scheme
+		student.SemesterResults[i].SemesterNumber = fmt.Sprintf("%d", offset+i+1)
+	}
+}
+
+// summarisePhases rebuilds student.Phases from the tagged semesters and sets
+// student.CGPI according to rule.
+func summarisePhases(student *resultTypes.StudentHtmlParsed, rule string) {
+	student.Phases = nil
+	index := map[string]int{}
+	for _, sem := range student.SemesterResults {
+		i, ok := index[sem.Phase]
+		if !ok {
+			i = len(student.Phases)
+			index[sem.Phase] = i
+			student.Phases = append(student.Phases, resultTypes.PhaseSummary{Phase: sem.Phase, Scheme: sem.Scheme})
+		}
+		phase := &student.Phases[i]
+		// the portal reports CGPI cumulatively, so the last semester of a phase holds the phase CGPI
+		phase.CGPI = sem.CGPI
+		phase.Semesters++
+		for _, subject := range sem.SubjectResults {
+			phase.Credits += subject.Credit
+		}
+	}
+	if len(student.Phases) == 0 {
+		return
+	}
+	if len(student.Phases) == 1 {
+		student.CGPI = student.Phases[0].CGPI
+		student.CGPIRule = ""
+		return
+	}
+	student.CGPI = overallCGPI(student.Phases, rule)
+	student.CGPIRule = rule
+}
+
+func overallCGPI(phases []resultTypes.PhaseSummary, rule string) float64 {
+	switch rule {
+	case CGPIRuleLatest:
+		return phases[len(phases)-1].CGPI
+	case CGPIRuleBachelor:
+		for _, phase := range phases {
+			if phase.Phase == resultTypes.PhaseBachelor {
+				return phase.CGPI
+			}
+		}
+		return phases[0].CGPI
+	case CGPIRuleMax:
+		best := phases[0].CGPI
+		for _, phase := range phases[1:] {
+			if phase.CGPI > best {
+				best = phase.CGPI
+			}
+		}
+		return best
+	default:
+		var points float64
+		var credits int64
+		for _, phase := range phases {
+			points += phase.CGPI * float64(phase.Credits)
+			credits += phase.Credits
+		}
+		if credits == 0 {
+			return phases[len(phases)-1].CGPI
+		}
+		return math.Round(points/float64(credits)*100) / 100
+	}
+}
+
+func dualDegreeCGPIRule() string {
+	switch rule := config.Get().DualDegreeCGPIRule; rule {
+	case CGPIRuleCreditWeighted, CGPIRuleLatest, CGPIRuleBachelor, CGPIRuleMax:
+		return rule
+	default:
+		return CGPIRuleCreditWeighted
+	}
+}
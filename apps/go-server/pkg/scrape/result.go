@@ -1,6 +1,7 @@
 package scrape
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -15,6 +16,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/kanakkholwal/go-server/pkg/upstream"
 	resultTypes "github.com/kanakkholwal/go-server/types"
 	"github.com/kanakkholwal/go-server/utils"
 
@@ -127,7 +129,10 @@ func ParseResultHtml(body io.ReadCloser) (user *resultTypes.StudentHtmlParsed, p
 							points, _ := strconv.Atoi(text)
 
 							subjectsResult[rowIndex-2].Points = int64(points)
-							subjectsResult[rowIndex-2].CGPI = float64(points) / float64(subjectsResult[rowIndex-2].Credit)
+							// the grade scale replaces this once the scheme is known; guard against a credit that failed to parse
+							if subjectsResult[rowIndex-2].Credit > 0 {
+								subjectsResult[rowIndex-2].CGPI = float64(points) / float64(subjectsResult[rowIndex-2].Credit)
+							}
 						}
 					}
 				})
@@ -142,11 +147,11 @@ func ParseResultHtml(body io.ReadCloser) (user *resultTypes.StudentHtmlParsed, p
 				if cellIndex == 1 {
 					user.SemesterResults[(tableIndex-2)/2].SGPI, _ = strconv.ParseFloat(text, 64)
 				} else if cellIndex == 2 {
-					user.SemesterResults[(tableIndex-2)/2].SGPITotal, _ = strconv.ParseInt(text, 64, 0)
+					user.SemesterResults[(tableIndex-2)/2].SGPITotal, _ = strconv.ParseInt(text, 10, 64)
 				} else if cellIndex == 3 {
 					user.SemesterResults[(tableIndex-2)/2].CGPI, _ = strconv.ParseFloat(text, 64)
 				} else if cellIndex == 4 {
-					user.SemesterResults[(tableIndex-2)/2].CGPITotal, _ = strconv.ParseInt(text, 64, 0)
+					user.SemesterResults[(tableIndex-2)/2].CGPITotal, _ = strconv.ParseInt(text, 10, 64)
 				}
 			})
 		}
@@ -166,14 +171,17 @@ func ParseResultHtml(body io.ReadCloser) (user *resultTypes.StudentHtmlParsed, p
 	return user, nil
 }
 
-func getResultHtml(rollNumber string, path string) (io.ReadCloser, error) {
+// getResultHtml posts the roll number to a result portal and returns the
+// answer page. Each call is its own portal session with its own cookies; the
+// connections come from the shared upstream pool. A status error on the post
+// drops the cached form tokens, which the portal may have expired.
+func getResultHtml(ctx context.Context, rollNumber string, path string) (io.ReadCloser, error) {
 	cookieJar, err := cookiejar.New(nil)
 	if err != nil {
 		return nil, err
 	}
-	httpClient := &http.Client{
-		Jar: cookieJar,
-	}
+	up := upstream.Default()
+	httpClient := up.HTTPClient(cookieJar)
 
 	var csrfToken, verToken string
 	if val, ok := tokenCache.Load(path); ok {
@@ -182,13 +190,16 @@ func getResultHtml(rollNumber string, path string) (io.ReadCloser, error) {
 		verToken = tokens.VerToken
 	} else {
 		// fetch tokens if not cached
-		formPageResponse, err := httpClient.Get(path)
+		formReq, err := http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return nil, err
+		}
+		formPage, err := up.Do(httpClient, formReq)
 		if err != nil {
 			return nil, err
 		}
-		defer formPageResponse.Body.Close()
 
-		formPageDoc, err := goquery.NewDocumentFromReader(formPageResponse.Body)
+		formPageDoc, err := goquery.NewDocumentFromReader(bytes.NewReader(formPage))
 		if err != nil {
 			return nil, err
 		}
@@ -212,7 +223,7 @@ func getResultHtml(rollNumber string, path string) (io.ReadCloser, error) {
 		"RequestVerificationToken": {verToken},
 		"B1":                       {"Submit"},
 	}
-	postReq, err := http.NewRequest(http.MethodPost, path, strings.NewReader(data.Encode()))
+	postReq, err := http.NewRequestWithContext(ctx, http.MethodPost, path, strings.NewReader(data.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -221,60 +232,83 @@ func getResultHtml(rollNumber string, path string) (io.ReadCloser, error) {
 
 	// this assumes at least one cookie was set during GET
 	// if not using the GET, you may have to separately fetch cookies if required
-	resp, err := httpClient.Do(postReq)
+	page, err := up.Do(httpClient, postReq)
+	var status *upstream.StatusError
+	if errors.As(err, &status) {
+		tokenCache.Delete(path)
+	}
 	if err != nil {
 		return nil, err
 	}
-	return resp.Body, nil
+	return io.NopCloser(bytes.NewReader(page)), nil
 }
 
+// GetResultByRollNumber fetches and parses the result of a roll number from
+// every portal it is published on. Dual degree students are merged into one
+// record whose semesters are tagged with their phase; if the secondary portal
+// fails, the primary result is returned with a warning instead of an error.
 func GetResultByRollNumber(rollNumber string) (*resultTypes.StudentHtmlParsed, error) {
-	paths := utils.GetUrlForRollNumber(rollNumber, false)
-	if len(paths) == 0 {
+	return GetResultByRollNumberContext(context.Background(), rollNumber)
+}
+
+// GetResultByRollNumberContext is GetResultByRollNumber with a context that
+// bounds every request to the portals.
+func GetResultByRollNumberContext(ctx context.Context, rollNumber string) (*resultTypes.StudentHtmlParsed, error) {
+	sources := utils.GetResultSources(rollNumber, false)
+	if len(sources) == 0 {
 		return nil, fmt.Errorf("invalid roll number %s | No result path found", rollNumber)
 	}
 	var student *resultTypes.StudentHtmlParsed
 
-	for idx, path := range paths {
-		log.Printf("Fetching result for roll number %s from %s\n", rollNumber, path)
+	for idx, source := range sources {
+		log.Printf("Fetching result for roll number %s from %s\n", rollNumber, source.URL)
 		// fetch the result html
-		resultHtml, err := getResultHtml(rollNumber, path)
+		resultHtml, err := getResultHtml(ctx, rollNumber, source.URL)
 		if err != nil {
-			return nil, fmt.Errorf("error for rollNumber %s: %w in getResultHtml", rollNumber, err)
-		}
-		if idx == 0 {
-			// first path is the one we want
-			student, err = ParseResultHtml(resultHtml)
-			if err != nil {
-				if errors.Is(err, RollNumberDoesNotExist) {
-					return nil, fmt.Errorf("error for rollNumber %s: %w", rollNumber, err)
-				}
-				return nil, fmt.Errorf("error for rollNumber %s: %w", rollNumber, err)
+			if idx == 0 {
+				return nil, fmt.Errorf("error for rollNumber %s: %w in getResultHtml", rollNumber, err)
 			}
-		} else {
-
-			excess_student, err := ParseResultHtml(resultHtml)
-			if err == nil && excess_student != nil {
-				log.Printf("error for rollNumber %s: %v\n", rollNumber, err)
-			}
-
-			for i := range excess_student.SemesterResults {
-				excess_student.SemesterResults[i].SemesterNumber = fmt.Sprintf("Masters Sem 0%d", i+1)
-			}
-			student.SemesterResults = append(student.SemesterResults, excess_student.SemesterResults...)
+			log.Printf("error for rollNumber %s: %v\n", rollNumber, err)
+			student.Warnings = append(student.Warnings, fmt.Sprintf("%s phase results unavailable from %s: %v", source.Phase, source.Scheme, err))
+			continue
+		}
+		resultHtml, err = notifyPage(rollNumber, source, resultHtml)
+		var parsed *resultTypes.StudentHtmlParsed
+		if err == nil {
+			parsed, err = ParseResultHtml(resultHtml)
+			resultHtml.Close()
+		}
+		student, err = mergeSource(student, idx, source, parsed, err)
+		if err != nil {
+			return nil, fmt.Errorf("error for rollNumber %s: %w", rollNumber, err)
+		}
+	}
+	enrichStudent(student)
+	notifyScraped(student)
+	return student, nil
 
-			if student.CGPI < excess_student.CGPI {
-				student.CGPI = excess_student.CGPI
-			}
-			if err != nil {
-				// for other paths, we just log the error
-				log.Printf("error for rollNumber %s: %v\n", rollNumber, err)
+}
 
-			}
+// mergeSource adds the result parsed from the idx-th source to student. The
+// first source is the primary one: without it there is no result, so its
+// error is returned. Later sources only add their semesters, and a failure
+// there is kept as a warning on the partial result.
+func mergeSource(student *resultTypes.StudentHtmlParsed, idx int, source utils.ResultSource, parsed *resultTypes.StudentHtmlParsed, err error) (*resultTypes.StudentHtmlParsed, error) {
+	if idx == 0 {
+		if err != nil {
+			return nil, err
 		}
+		tagSemesters(parsed, source, 0)
+		return parsed, nil
+	}
+	if err != nil || parsed == nil {
+		log.Printf("error for rollNumber %s: %v\n", student.RollNumber, err)
+		student.Warnings = append(student.Warnings, fmt.Sprintf("%s phase results could not be parsed from %s: %v", source.Phase, source.Scheme, err))
+		return student, nil
 	}
+	tagSemesters(parsed, source, len(student.SemesterResults))
+	student.SemesterResults = append(student.SemesterResults, parsed.SemesterResults...)
 	return student, nil
-
 }
 
 func GetResultsFromWeb(forOnlyBatch int) []resultTypes.StudentHtmlParsed {
@@ -286,13 +320,18 @@ func GetResultsFromWeb(forOnlyBatch int) []resultTypes.StudentHtmlParsed {
 	var students []resultTypes.StudentHtmlParsed
 
 	processNext := func(rollNumber string) (*resultTypes.StudentHtmlParsed, error) {
-		resultHtml, err := getResultHtml(rollNumber, utils.GetUrlForRollNumber(rollNumber, false)[0])
+		source := utils.GetResultSources(rollNumber, false)[0]
+		resultHtml, err := getResultHtml(context.Background(), rollNumber, source.URL)
 		if err != nil {
 			err = fmt.Errorf("error for rollNumber %s: %w in getResultHtml", rollNumber, err)
 			return nil, err
 		}
+		defer resultHtml.Close()
 		student, err := ParseResultHtml(resultHtml)
 		if err == nil && student != nil {
+			tagSemesters(student, source, 0)
+			enrichStudent(student)
+			notifyScraped(student)
 			return student, nil
 		} else {
 			err = fmt.Errorf("error for rollNumber %s: %w", rollNumber, err)
@@ -333,25 +372,24 @@ type ScrapeResult struct {
 	Error      string                         `json:"error,omitempty"`
 }
 
-func ScrapeInBulk(ctx context.Context, rollNumbers []string, concurrency int, delay time.Duration) []ScrapeResult {
+// ScrapeEach scrapes the roll numbers with the given number of workers, at
+// most one upstream lookup per delay, and hands every result to onResult as
+// soon as it arrives. onResult is called from the caller's goroutine. It
+// returns the number of results delivered, which is less than
+// len(rollNumbers) only when ctx is cancelled.
+func ScrapeEach(ctx context.Context, rollNumbers []string, concurrency int, delay time.Duration, onResult func(ScrapeResult)) int {
 	rolls := make(chan string)
 	results := make(chan ScrapeResult)
-	collected := make([]ScrapeResult, 0, len(rollNumbers))
-	total := len(rollNumbers)
-	fmt.Println("Scraping in bulk...", total, "roll numbers")
 	ticker := time.NewTicker(delay)
 	defer ticker.Stop()
 
-	var mu sync.Mutex
-	progress := 0
-
 	// Start workers
 	for range concurrency {
 		go func() {
 			for roll := range rolls {
 				select {
 				case <-ticker.C:
-					data, err := GetResultByRollNumber(roll)
+					data, err := GetResultByRollNumberContext(ctx, roll)
 					res := ScrapeResult{RollNumber: roll}
 					if err != nil {
 						res.Error = err.Error()
@@ -372,6 +410,7 @@ func ScrapeInBulk(ctx context.Context, rollNumbers []string, concurrency int, de
 
 	// Feed roll numbers
 	go func() {
+		defer close(rolls)
 		for _, roll := range rollNumbers {
 			select {
 			case rolls <- roll:
@@ -379,22 +418,32 @@ func ScrapeInBulk(ctx context.Context, rollNumbers []string, concurrency int, de
 				return
 			}
 		}
-		close(rolls)
 	}()
 
-	// Collect results and update progress
-	for range total {
+	delivered := 0
+	for range rollNumbers {
 		select {
 		case res := <-results:
-			mu.Lock()
-			progress++
-			fmt.Printf("\rProgress: %d/%d (%f) \n", progress, total, float64(progress)/float64(total)*100)
-			mu.Unlock()
-			collected = append(collected, res)
+			delivered++
+			onResult(res)
 		case <-ctx.Done():
-			return collected
+			return delivered
 		}
 	}
+	return delivered
+}
+
+func ScrapeInBulk(ctx context.Context, rollNumbers []string, concurrency int, delay time.Duration) []ScrapeResult {
+	collected := make([]ScrapeResult, 0, len(rollNumbers))
+	total := len(rollNumbers)
+	fmt.Println("Scraping in bulk...", total, "roll numbers")
+
+	// Collect results and update progress
+	ScrapeEach(ctx, rollNumbers, concurrency, delay, func(res ScrapeResult) {
+		collected = append(collected, res)
+		progress := len(collected)
+		fmt.Printf("\rProgress: %d/%d (%f) \n", progress, total, float64(progress)/float64(total)*100)
+	})
 
 	fmt.Printf("\nScraping completed: %d results collected\n", len(collected))
 	return collected
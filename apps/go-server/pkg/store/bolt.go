@@ -0,0 +1,95 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.etcd.io/bbolt"
+
+	"github.com/kanakkholwal/go-server/types"
+)
+
+// historyBucket holds every revision under "<ROLL>/<version>", with the
+// version zero padded so revisions of a roll sort in order.
+var historyBucket = []byte("history")
+
+// Bolt is a Store persisted in a single bbolt file. Every revision is kept in
+// memory as well, so reads never touch the disk.
+type Bolt struct {
+	*Memory
+	db *bbolt.DB
+}
+
+// Open opens (or creates) the store file at path and loads its contents.
+func Open(path string) (*Bolt, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("create store directory: %w", err)
+		}
+	}
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("open result store: %w", err)
+	}
+	b := &Bolt{Memory: NewMemory(), db: db}
+	err = db.Update(func(tx *bbolt.Tx) error {
+		documents, err := tx.CreateBucketIfNotExists(documentsBucket)
+		if err != nil {
+			return err
+		}
+		err = documents.ForEach(func(k, v []byte) error {
+			b.Memory.saveDocument(string(k), append([]byte(nil), v...))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		bucket, err := tx.CreateBucketIfNotExists(historyBucket)
+		if err != nil {
+			return err
+		}
+		return bucket.ForEach(func(k, v []byte) error {
+			var revision Revision
+			if err := json.Unmarshal(v, &revision); err != nil {
+				return fmt.Errorf("decode revision %s: %w", k, err)
+			}
+			b.Memory.load(revision)
+			return nil
+		})
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return b, nil
+}
+
+// Upsert records the student in memory and, when it changed, on disk.
+// Write failures are logged rather than returned so a broken disk does not
+// fail the scrape that produced the result.
+func (b *Bolt) Upsert(student *types.StudentHtmlParsed) {
+	revision, changed := b.Memory.upsert(student, time.Now())
+	if !changed {
+		return
+	}
+	raw, err := json.Marshal(revision)
+	if err != nil {
+		log.Printf("store: encode %s: %v", revision.Student.RollNumber, err)
+		return
+	}
+	key := fmt.Sprintf("%s/%06d", rollKey(revision.Student.RollNumber), revision.Version)
+	err = b.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(historyBucket).Put([]byte(key), raw)
+	})
+	if err != nil {
+		log.Printf("store: write %s: %v", key, err)
+	}
+}
+
+func (b *Bolt) Close() error {
+	return b.db.Close()
+}
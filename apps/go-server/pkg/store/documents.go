@@ -0,0 +1,89 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.etcd.io/bbolt"
+)
+
+// documentsBucket holds the documents of SaveDocument under their name.
+var documentsBucket = []byte("documents")
+
+func (m *Memory) SaveDocument(name string, v any) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode document %s: %w", name, err)
+	}
+	m.saveDocument(name, raw)
+	return nil
+}
+
+func (m *Memory) saveDocument(name string, raw []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.documents[name] = raw
+}
+
+func (m *Memory) LoadDocument(name string, v any) (bool, error) {
+	m.mu.RLock()
+	raw, ok := m.documents[name]
+	m.mu.RUnlock()
+	if !ok {
+		return false, nil
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return true, fmt.Errorf("decode document %s: %w", name, err)
+	}
+	return true, nil
+}
+
+func (m *Memory) DeleteDocument(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.documents, name)
+	return nil
+}
+
+func (m *Memory) DocumentNames(prefix string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	names := []string{}
+	for name := range m.documents {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SaveDocument writes the document to disk before keeping it in memory, so
+// an error means nothing changed.
+func (b *Bolt) SaveDocument(name string, v any) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode document %s: %w", name, err)
+	}
+	err = b.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(documentsBucket).Put([]byte(name), raw)
+	})
+	if err != nil {
+		return fmt.Errorf("write document %s: %w", name, err)
+	}
+	b.Memory.saveDocument(name, raw)
+	return nil
+}
+
+// DeleteDocument removes the document from disk before forgetting it.
+func (b *Bolt) DeleteDocument(name string) error {
+	err := b.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(documentsBucket).Delete([]byte(name))
+	})
+	if err != nil {
+		return fmt.Errorf("delete document %s: %w", name, err)
+	}
+	return b.Memory.DeleteDocument(name)
+}
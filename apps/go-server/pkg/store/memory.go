@@ -0,0 +1,112 @@
+package store
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kanakkholwal/go-server/types"
+)
+
+// Memory is a Store that lives only as long as the process.
+type Memory struct {
+	mu      sync.RWMutex
+	history map[string][]Revision
+	prints  map[string][32]byte
+	version uint64
+	// documents holds the JSON encoded documents of SaveDocument
+	documents map[string][]byte
+}
+
+func NewMemory() *Memory {
+	return &Memory{history: map[string][]Revision{}, prints: map[string][32]byte{}, documents: map[string][]byte{}}
+}
+
+// Upsert stores a copy of the student as a new revision of its roll number,
+// unless it is identical to the latest revision.
+func (m *Memory) Upsert(student *types.StudentHtmlParsed) {
+	m.upsert(student, time.Now())
+}
+
+// upsert returns the revision it recorded, or false when nothing changed.
+func (m *Memory) upsert(student *types.StudentHtmlParsed, at time.Time) (Revision, bool) {
+	if student == nil || student.RollNumber == "" {
+		return Revision{}, false
+	}
+	key := rollKey(student.RollNumber)
+	record := *student
+	record.Standing = nil
+	print := fingerprint(record)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if prev, ok := m.prints[key]; ok && prev == print {
+		return Revision{}, false
+	}
+	revision := Revision{Version: len(m.history[key]) + 1, ScrapedAt: at, Student: record}
+	m.history[key] = append(m.history[key], revision)
+	m.prints[key] = print
+	m.version++
+	return revision, true
+}
+
+// load adds a revision read back from disk without bumping the version.
+func (m *Memory) load(revision Revision) {
+	key := rollKey(revision.Student.RollNumber)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.history[key] = append(m.history[key], revision)
+	sort.Slice(m.history[key], func(i, j int) bool { return m.history[key][i].Version < m.history[key][j].Version })
+	latest := m.history[key][len(m.history[key])-1]
+	m.prints[key] = fingerprint(latest.Student)
+}
+
+// Get returns the latest stored record of a roll number.
+func (m *Memory) Get(rollNo string) (types.StudentHtmlParsed, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	revisions := m.history[rollKey(rollNo)]
+	if len(revisions) == 0 {
+		return types.StudentHtmlParsed{}, false
+	}
+	return revisions[len(revisions)-1].Student, true
+}
+
+// History returns every stored revision of a roll number, oldest first.
+func (m *Memory) History(rollNo string) []Revision {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]Revision(nil), m.history[rollKey(rollNo)]...)
+}
+
+// Select returns the latest records accepted by match (all records when match
+// is nil), sorted by roll number.
+func (m *Memory) Select(match func(student *types.StudentHtmlParsed) bool) []types.StudentHtmlParsed {
+	m.mu.RLock()
+	out := make([]types.StudentHtmlParsed, 0, len(m.history))
+	for _, revisions := range m.history {
+		student := revisions[len(revisions)-1].Student
+		if match == nil || match(&student) {
+			out = append(out, student)
+		}
+	}
+	m.mu.RUnlock()
+	sort.Slice(out, func(i, j int) bool { return out[i].RollNumber < out[j].RollNumber })
+	return out
+}
+
+func (m *Memory) Query(q Query) Page {
+	return runQuery(m.Select(q.Match), q)
+}
+
+func (m *Memory) Version() uint64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.version
+}
+
+func (m *Memory) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.history)
+}
@@ -0,0 +1,184 @@
+package store
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kanakkholwal/go-server/types"
+)
+
+// Store keeps the latest result of every roll number together with the
+// earlier versions of it.
+type Store interface {
+	// Upsert stores a scraped student. A new version is only recorded when the
+	// result differs from the latest stored one.
+	Upsert(student *types.StudentHtmlParsed)
+	Get(rollNo string) (types.StudentHtmlParsed, bool)
+	History(rollNo string) []Revision
+	Select(match func(student *types.StudentHtmlParsed) bool) []types.StudentHtmlParsed
+	Query(q Query) Page
+	// Version increases with every write, so callers can tell when data they
+	// derived from the store is out of date.
+	Version() uint64
+	Len() int
+	// SaveDocument stores v, encoded as JSON, under name, replacing what was
+	// saved there before. Documents hold data kept next to the results, such
+	// as the faculty directory.
+	SaveDocument(name string, v any) error
+	// LoadDocument decodes the document saved under name into v and reports
+	// whether there was one.
+	LoadDocument(name string, v any) (bool, error)
+	// DeleteDocument removes the document saved under name, if any.
+	DeleteDocument(name string) error
+	// DocumentNames lists the names of the saved documents starting with
+	// prefix, sorted.
+	DocumentNames(prefix string) []string
+}
+
+// Revision is one stored version of a student's result.
+type Revision struct {
+	Version   int                     `json:"version"`
+	ScrapedAt time.Time               `json:"scrapedAt"`
+	Student   types.StudentHtmlParsed `json:"student"`
+}
+
+// Sort keys accepted by Query; prefix with "-" for descending order.
+const (
+	SortRollNumber = "rollNo"
+	SortName       = "name"
+	SortCGPI       = "cgpi"
+	SortBatch      = "batch"
+)
+
+// Query filters the latest stored results. Empty fields match everything.
+// Branches and Programmes hold the names stored on results, e.g.
+// "Computer Science and Engineering" and "B.Tech".
+type Query struct {
+	Batches    []int
+	Branches   []string
+	Programmes []string
+	MinCGPI    *float64
+	MaxCGPI    *float64
+	// Name matches a case-insensitive substring of the name or roll number.
+	Name  string
+	Sort  string
+	Page  int
+	Limit int
+}
+
+type Page struct {
+	Total   int                       `json:"total"`
+	Page    int                       `json:"page"`
+	Limit   int                       `json:"limit"`
+	Results []types.StudentHtmlParsed `json:"results"`
+}
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 500
+)
+
+// Match reports whether a student passes the filters of the query.
+func (q Query) Match(student *types.StudentHtmlParsed) bool {
+	if len(q.Batches) > 0 && !containsInt(q.Batches, student.Batch) {
+		return false
+	}
+	if len(q.Branches) > 0 && !containsFold(q.Branches, student.Branch) {
+		return false
+	}
+	if len(q.Programmes) > 0 && !containsFold(q.Programmes, student.Programme) {
+		return false
+	}
+	if q.MinCGPI != nil && student.CGPI < *q.MinCGPI {
+		return false
+	}
+	if q.MaxCGPI != nil && student.CGPI > *q.MaxCGPI {
+		return false
+	}
+	if q.Name != "" {
+		needle := strings.ToLower(strings.TrimSpace(q.Name))
+		if !strings.Contains(strings.ToLower(student.Name), needle) && !strings.Contains(strings.ToLower(student.RollNumber), needle) {
+			return false
+		}
+	}
+	return true
+}
+
+// runQuery filters, sorts and paginates students.
+func runQuery(students []types.StudentHtmlParsed, q Query) Page {
+	if q.Limit <= 0 {
+		q.Limit = DefaultLimit
+	}
+	if q.Limit > MaxLimit {
+		q.Limit = MaxLimit
+	}
+	if q.Page <= 0 {
+		q.Page = 1
+	}
+	desc := strings.HasPrefix(q.Sort, "-")
+	key := strings.TrimPrefix(q.Sort, "-")
+	less := func(a, b *types.StudentHtmlParsed) bool {
+		switch key {
+		case SortName:
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+		case SortCGPI:
+			if a.CGPI != b.CGPI {
+				return a.CGPI < b.CGPI
+			}
+		case SortBatch:
+			if a.Batch != b.Batch {
+				return a.Batch < b.Batch
+			}
+		}
+		return a.RollNumber < b.RollNumber
+	}
+	sort.SliceStable(students, func(i, j int) bool {
+		if desc {
+			return less(&students[j], &students[i])
+		}
+		return less(&students[i], &students[j])
+	})
+
+	page := Page{Total: len(students), Page: q.Page, Limit: q.Limit, Results: []types.StudentHtmlParsed{}}
+	start := (q.Page - 1) * q.Limit
+	if start < len(students) {
+		end := min(start+q.Limit, len(students))
+		page.Results = students[start:end]
+	}
+	return page
+}
+
+// fingerprint identifies the content of a result, ignoring fields that are
+// computed per request.
+func fingerprint(student types.StudentHtmlParsed) [32]byte {
+	student.Standing = nil
+	raw, _ := json.Marshal(student)
+	return sha256.Sum256(raw)
+}
+
+func rollKey(rollNo string) string {
+	return strings.ToUpper(strings.TrimSpace(rollNo))
+}
+
+func containsInt(list []int, v int) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(list []string, v string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, v) {
+			return true
+		}
+	}
+	return false
+}
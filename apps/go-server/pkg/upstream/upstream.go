@@ -0,0 +1,204 @@
+// Package upstream is the HTTP client every request to a college server goes
+// through. All requests share one pooled transport, are bounded by a
+// deadline and a body size limit, and fail with typed errors for answers
+// other than 200 OK.
+package upstream
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kanakkholwal/go-server/config"
+)
+
+// UserAgent identifies the server to the college servers.
+const UserAgent = "college-ecosystem-scraper/1.0 (+https://nith.ac.in)"
+
+// ErrBodyTooLarge is returned for answers larger than Options.MaxBodyBytes.
+var ErrBodyTooLarge = errors.New("upstream answer exceeds the size limit")
+
+// StatusError is an answer other than 200 OK.
+type StatusError struct {
+	URL        string
+	StatusCode int
+	Status     string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s answered %s", e.URL, e.Status)
+}
+
+// Temporary reports a rate limit or server error, worth retrying later.
+func (e *StatusError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// Retryable reports whether a request that failed with err may work when
+// sent again: network errors, timeouts of a single request and temporary
+// status errors. Cancellation by the caller is not retryable.
+func Retryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, ErrBodyTooLarge) {
+		return false
+	}
+	var status *StatusError
+	if errors.As(err, &status) {
+		return status.Temporary()
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+type Options struct {
+	// Timeout bounds one request including reading its answer.
+	Timeout time.Duration
+	// MaxBodyBytes is the largest answer read, after decompression.
+	MaxBodyBytes int64
+	// MaxConnsPerHost caps the open connections to one host.
+	MaxConnsPerHost int
+	// Proxy is an HTTP proxy URL; when empty the proxy environment
+	// variables apply.
+	Proxy     string
+	UserAgent string
+}
+
+// Client sends requests through the shared transport.
+type Client struct {
+	opts      Options
+	transport *http.Transport
+}
+
+// New builds a client, filling unset options with the defaults of the
+// configuration.
+func New(opts Options) (*Client, error) {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 30 * time.Second
+	}
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = 8 << 20
+	}
+	if opts.MaxConnsPerHost <= 0 {
+		opts.MaxConnsPerHost = 16
+	}
+	if opts.UserAgent == "" {
+		opts.UserAgent = UserAgent
+	}
+	proxy := http.ProxyFromEnvironment
+	if opts.Proxy != "" {
+		proxyURL, err := url.Parse(opts.Proxy)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", opts.Proxy)
+		}
+		proxy = http.ProxyURL(proxyURL)
+	}
+	dialer := &net.Dialer{Timeout: 10 * time.Second, KeepAlive: 30 * time.Second}
+	transport := &http.Transport{
+		Proxy:                 proxy,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   opts.MaxConnsPerHost,
+		MaxConnsPerHost:       opts.MaxConnsPerHost,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: opts.Timeout,
+		ExpectContinueTimeout: time.Second,
+		// gzip is asked for and decoded by Do, so the size limit applies to
+		// the decoded answer
+		DisableCompression: true,
+	}
+	return &Client{opts: opts, transport: transport}, nil
+}
+
+var (
+	defaultOnce   sync.Once
+	defaultClient *Client
+)
+
+// Default returns the process wide client, configured from config.Get on
+// first use. An invalid UPSTREAM_PROXY is logged and ignored.
+func Default() *Client {
+	defaultOnce.Do(func() {
+		cfg := config.Get()
+		opts := Options{
+			Timeout:         cfg.UpstreamTimeout,
+			MaxBodyBytes:    int64(cfg.UpstreamMaxBodyBytes),
+			MaxConnsPerHost: cfg.UpstreamMaxConnsPerHost,
+			Proxy:           cfg.UpstreamProxy,
+		}
+		client, err := New(opts)
+		if err != nil {
+			log.Printf("upstream: %v, using the proxy environment instead", err)
+			opts.Proxy = ""
+			client, _ = New(opts)
+		}
+		defaultClient = client
+	})
+	return defaultClient
+}
+
+// HTTPClient returns an http.Client on the shared transport. A session that
+// needs cookies passes its own jar; jar may be nil.
+func (c *Client) HTTPClient(jar http.CookieJar) *http.Client {
+	return &http.Client{Transport: c.transport, Jar: jar}
+}
+
+// Get fetches url with a client without cookies.
+func (c *Client) Get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(c.HTTPClient(nil), req)
+}
+
+// Do sends req through client, which should come from HTTPClient, and reads
+// the whole answer within the request timeout. Answers other than 200 OK
+// fail with a *StatusError; answers over the size limit with ErrBodyTooLarge.
+func (c *Client) Do(client *http.Client, req *http.Request) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), c.opts.Timeout)
+	defer cancel()
+	req = req.WithContext(ctx)
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", c.opts.UserAgent)
+	}
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		// drain a little so the connection can be reused
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 4<<10))
+		return nil, &StatusError{URL: req.URL.String(), StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	var body io.Reader = resp.Body
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid gzip answer: %w", req.URL, err)
+		}
+		defer gz.Close()
+		body = gz
+	}
+	data, err := io.ReadAll(io.LimitReader(body, c.opts.MaxBodyBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > c.opts.MaxBodyBytes {
+		return nil, fmt.Errorf("%s: %w of %d bytes", req.URL, ErrBodyTooLarge, c.opts.MaxBodyBytes)
+	}
+	return data, nil
+}
@@ -0,0 +1,182 @@
+package validate
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/kanakkholwal/go-server/pkg/grades"
+	"github.com/kanakkholwal/go-server/types"
+)
+
+// tolerance absorbs the two decimal rounding of the SGPI/CGPI figures printed
+// on the result portal.
+const tolerance = 0.011
+
+// Quality flag codes.
+const (
+	MissingName       = "missing_name"
+	NoSemesters       = "no_semesters"
+	EmptySemester     = "empty_semester"
+	ZeroCredit        = "zero_credit"
+	DuplicateCourse   = "duplicate_course"
+	DuplicateSemester = "duplicate_semester"
+	SGPIMismatch      = "sgpi_mismatch"
+	SGPITotalMismatch = "sgpi_total_mismatch"
+	CGPITotalMismatch = "cgpi_total_mismatch"
+	GPIOutOfRange     = "gpi_out_of_range"
+	CGPIJump          = "cgpi_jump"
+	UnknownGrade      = "unknown_grade"
+	// GradePointsMismatch is raised when the points printed for a course
+	// differ from credit × grade point on the scheme's grade scale.
+	GradePointsMismatch = "grade_points_mismatch"
+)
+
+// Validate runs every consistency check against a parsed student and returns
+// the findings in semester order. It never modifies the student.
+func Validate(student *types.StudentHtmlParsed) []types.QualityFlag {
+	flags := []types.QualityFlag{}
+	if student == nil {
+		return flags
+	}
+	add := func(code, severity, semester, course, format string, args ...any) {
+		flags = append(flags, types.QualityFlag{
+			Code:     code,
+			Severity: severity,
+			Semester: semester,
+			Course:   course,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	if strings.TrimSpace(student.Name) == "" {
+		add(MissingName, types.SeverityError, "", "", "student name is missing")
+	}
+	if len(student.SemesterResults) == 0 {
+		add(NoSemesters, types.SeverityError, "", "", "no semester results")
+		return flags
+	}
+
+	seenSemester := map[string]bool{}
+	seenCourseSet := map[string]string{}
+	// cumulative figures restart with every programme phase
+	prev := map[string]*types.SemesterResult{}
+
+	for i := range student.SemesterResults {
+		sem := &student.SemesterResults[i]
+		label := sem.SemesterNumber
+
+		key := sem.Phase + "/" + sem.SemesterNumber
+		if seenSemester[key] {
+			add(DuplicateSemester, types.SeverityError, label, "", "semester %s appears more than once", label)
+		}
+		seenSemester[key] = true
+
+		if sem.SGPI < 0 || sem.SGPI > 10 || sem.CGPI < 0 || sem.CGPI > 10 {
+			add(GPIOutOfRange, types.SeverityError, label, "", "SGPI %.2f / CGPI %.2f outside 0-10", sem.SGPI, sem.CGPI)
+		}
+
+		if len(sem.SubjectResults) == 0 {
+			add(EmptySemester, types.SeverityError, label, "", "semester %s has no courses", label)
+			continue
+		}
+
+		var credits, points int64
+		codes := map[string]bool{}
+		codeList := []string{}
+		scale := grades.ForScheme(sem.Scheme)
+		for _, subject := range sem.SubjectResults {
+			code := strings.ToUpper(strings.TrimSpace(subject.SubjectCode))
+			if codes[code] {
+				add(DuplicateCourse, types.SeverityError, label, subject.SubjectCode, "course %s listed more than once", subject.SubjectCode)
+			}
+			codes[code] = true
+			codeList = append(codeList, code)
+
+			grade, known := scale.Lookup(subject.Grade)
+			if !known {
+				add(UnknownGrade, types.SeverityWarning, label, subject.SubjectCode, "grade %q of course %s is not on the %s scale", subject.Grade, subject.SubjectCode, scale.Name)
+			}
+			if subject.Credit <= 0 {
+				if !known || !grade.Audit {
+					add(ZeroCredit, types.SeverityWarning, label, subject.SubjectCode, "course %s has no credits", subject.SubjectCode)
+				}
+				continue
+			}
+			if known && !grade.Audit && subject.Points != int64(math.Round(float64(subject.Credit)*grade.Point)) {
+				add(GradePointsMismatch, types.SeverityWarning, label, subject.SubjectCode, "course %s: grade %s over %d credits should give %.0f points, got %d", subject.SubjectCode, grade.Letter, subject.Credit, float64(subject.Credit)*grade.Point, subject.Points)
+			}
+			if scale.CountsTowardsCGPI(subject) {
+				credits += subject.Credit
+				points += subject.Points
+			}
+		}
+		if credits == 0 {
+			add(ZeroCredit, types.SeverityError, label, "", "semester %s has no credits in total", label)
+		}
+
+		courseSet := strings.Join(sortedCopy(codeList), ",")
+		if other, ok := seenCourseSet[courseSet]; ok {
+			add(DuplicateSemester, types.SeverityWarning, label, "", "semester %s has the same courses as semester %s", label, other)
+		} else {
+			seenCourseSet[courseSet] = label
+		}
+
+		if sem.SGPITotal > 0 && sem.SGPITotal != points {
+			add(SGPITotalMismatch, types.SeverityError, label, "", "SGPI total %d does not match course points %d", sem.SGPITotal, points)
+		}
+		if credits > 0 {
+			recomputed := round2(float64(points) / float64(credits))
+			if math.Abs(recomputed-sem.SGPI) > tolerance {
+				add(SGPIMismatch, types.SeverityError, label, "", "reported SGPI %.2f, courses give %.2f", sem.SGPI, recomputed)
+			}
+		}
+
+		if p, ok := prev[sem.Phase]; ok {
+			if p.CGPITotal > 0 && sem.CGPITotal > 0 && sem.SGPITotal > 0 && sem.CGPITotal != p.CGPITotal+sem.SGPITotal {
+				add(CGPITotalMismatch, types.SeverityWarning, label, "", "CGPI total %d is not previous total %d plus SGPI total %d", sem.CGPITotal, p.CGPITotal, sem.SGPITotal)
+			}
+			// a cumulative average must move from the previous CGPI towards this SGPI
+			lo, hi := math.Min(p.CGPI, sem.SGPI), math.Max(p.CGPI, sem.SGPI)
+			if sem.CGPI < lo-tolerance || sem.CGPI > hi+tolerance {
+				add(CGPIJump, types.SeverityWarning, label, "", "CGPI %.2f is not between previous CGPI %.2f and SGPI %.2f", sem.CGPI, p.CGPI, sem.SGPI)
+			}
+		} else {
+			if sem.SGPITotal > 0 && sem.CGPITotal > 0 && sem.CGPITotal != sem.SGPITotal {
+				add(CGPITotalMismatch, types.SeverityWarning, label, "", "first CGPI total %d differs from SGPI total %d", sem.CGPITotal, sem.SGPITotal)
+			}
+			if math.Abs(sem.CGPI-sem.SGPI) > tolerance {
+				add(CGPIJump, types.SeverityWarning, label, "", "first semester CGPI %.2f differs from SGPI %.2f", sem.CGPI, sem.SGPI)
+			}
+		}
+		prev[sem.Phase] = sem
+	}
+	return flags
+}
+
+// IsAbnormal reports whether any of the flags is an error.
+func IsAbnormal(flags []types.QualityFlag) bool {
+	for _, flag := range flags {
+		if flag.Severity == types.SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply validates the student and stores the findings on it.
+func Apply(student *types.StudentHtmlParsed) {
+	student.QualityFlags = Validate(student)
+	student.Abnormal = IsAbnormal(student.QualityFlags)
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func sortedCopy(list []string) []string {
+	out := append([]string(nil), list...)
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,106 @@
+package routes
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kanakkholwal/go-server/pkg/academic"
+	"github.com/kanakkholwal/go-server/pkg/grades"
+	"github.com/kanakkholwal/go-server/types"
+)
+
+type backlogEntry struct {
+	RollNumber     string               `json:"rollNo"`
+	Name           string               `json:"name"`
+	Branch         string               `json:"branch"`
+	Batch          int                  `json:"batch"`
+	AcademicStatus types.AcademicStatus `json:"academicStatus"`
+}
+
+type branchChangeEntry struct {
+	RollNumber string                `json:"rollNo"`
+	Name       string                `json:"name"`
+	Batch      int                   `json:"batch"`
+	Programme  string                `json:"programme"`
+	BranchInfo types.BranchInference `json:"branchInfo"`
+}
+
+func registerAcademicRoutes(router fiber.Router) {
+	// grade scales in use; ?scheme=scheme22 returns the one applied to a scheme
+	router.Get("/grade-scales", func(c *fiber.Ctx) error {
+		if scheme := c.Query("scheme"); scheme != "" {
+			return c.JSON(grades.ForScheme(scheme))
+		}
+		return c.JSON(grades.Scales())
+	})
+
+	// list students of a cohort by academic status, from stored results or,
+	// for a few roll numbers given through include, scraped live
+	// ?batch=2022&branch=cs&status=active_backlog|cleared_backlog|clear|any_backlog
+	router.Get("/backlogs", func(c *fiber.Ctx) error {
+		filter := c.Query("status", "any_backlog")
+		switch filter {
+		case types.StatusClear, types.StatusActiveBacklog, types.StatusClearedBacklog, "any_backlog":
+		default:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "status should be one of clear, active_backlog, cleared_backlog or any_backlog"})
+		}
+		cohort, status, err := academicCohortQuery(c)
+		if err != nil {
+			return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		entries := []backlogEntry{}
+		for _, student := range cohort.students {
+			st := academic.Analyze(&student)
+			if !academic.MatchesStatus(st, filter) {
+				continue
+			}
+			entries = append(entries, backlogEntry{
+				RollNumber:     student.RollNumber,
+				Name:           student.Name,
+				Branch:         student.Branch,
+				Batch:          student.Batch,
+				AcademicStatus: st,
+			})
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].RollNumber < entries[j].RollNumber })
+		return sendCohortScrape(c, cohort.requested, entries, cohort.missing)
+	})
+
+	// report students of a cohort whose courses point to a different branch
+	// than their roll number, for admin review; the cohort is read like
+	// /backlogs
+	// ?batch=2022&branch=cs&minConfidence=0.6
+	router.Get("/branch-changes", func(c *fiber.Ctx) error {
+		minConfidence := academic.MinBranchConfidence
+		if raw := c.Query("minConfidence"); raw != "" {
+			v, err := strconv.ParseFloat(raw, 64)
+			if err != nil || v < 0 || v > 1 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "minConfidence should be a number between 0 and 1"})
+			}
+			minConfidence = v
+		}
+		cohort, status, err := academicCohortQuery(c)
+		if err != nil {
+			return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		changes := []branchChangeEntry{}
+		for _, student := range cohort.students {
+			inference := academic.InferBranch(&student)
+			if !inference.Changed || inference.Confidence < minConfidence {
+				continue
+			}
+			changes = append(changes, branchChangeEntry{
+				RollNumber: student.RollNumber,
+				Name:       student.Name,
+				Batch:      student.Batch,
+				Programme:  student.Programme,
+				BranchInfo: inference,
+			})
+		}
+		sort.Slice(changes, func(i, j int) bool { return changes[i].RollNumber < changes[j].RollNumber })
+		return sendCohortScrape(c, cohort.requested, changes, cohort.missing)
+	})
+}
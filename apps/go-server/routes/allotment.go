@@ -0,0 +1,229 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/kanakkholwal/go-server/config"
+	"github.com/kanakkholwal/go-server/middleware"
+	"github.com/kanakkholwal/go-server/pkg/allotment"
+	"github.com/kanakkholwal/go-server/pkg/importer"
+)
+
+// allotmentResult is an allotment as returned and as saved in the result
+// store. Dry runs have no ID and are not saved.
+type allotmentResult struct {
+	ID        string    `json:"id,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	DryRun    bool      `json:"dryRun"`
+	*allotment.Allocation
+}
+
+const allotmentPrefix = "allotments/"
+
+func allotmentDocument(id string) string {
+	return allotmentPrefix + id
+}
+
+// expired reports whether a saved allotment is past config.AllotmentRetention.
+func (r allotmentResult) expired(now time.Time) bool {
+	retention := config.Get().AllotmentRetention
+	return retention > 0 && now.Sub(r.CreatedAt) > retention
+}
+
+// pruneAllotments deletes the saved allotments past their retention. It runs
+// whenever an allotment is saved, which is rare enough to read them all.
+func pruneAllotments(now time.Time) {
+	if config.Get().AllotmentRetention <= 0 {
+		return
+	}
+	for _, name := range resultStore.DocumentNames(allotmentPrefix) {
+		var saved struct {
+			CreatedAt time.Time `json:"createdAt"`
+		}
+		if ok, err := resultStore.LoadDocument(name, &saved); !ok || err != nil {
+			continue
+		}
+		if (allotmentResult{CreatedAt: saved.CreatedAt}).expired(now) {
+			if err := resultStore.DeleteDocument(name); err != nil {
+				log.Printf("allotments: %v", err)
+			}
+		}
+	}
+}
+
+// allotmentRequest reads the multipart form of POST /hostels/allotment. JSON
+// fields follow the Node allotment: fieldMapping {"rollNo": "Roll No"},
+// roomDistribution {"2": 40}, extraFields ["Hometown"]. Rules come as a JSON
+// "rules" field or as the separate gender, soePriority, priority and groupBy
+// fields.
+func allotmentRequest(c *fiber.Ctx) (allotment.Request, error) {
+	var req allotment.Request
+	file, err := c.FormFile("file")
+	if err != nil {
+		return req, fmt.Errorf("upload the students as a multipart file field")
+	}
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(file.Filename)), ".")
+	if format != importer.FormatCSV && format != importer.FormatXLSX {
+		return req, fmt.Errorf("the students file should be .csv or .xlsx")
+	}
+	if req.Rows, err = readUpload(file, format, c.FormValue("sheet")); err != nil {
+		return req, err
+	}
+
+	form := func(v any, names ...string) error {
+		for _, name := range names {
+			if raw := c.FormValue(name); raw != "" {
+				if err := json.Unmarshal([]byte(raw), v); err != nil {
+					return fmt.Errorf("invalid %s: %w", name, err)
+				}
+				return nil
+			}
+		}
+		return nil
+	}
+	if err := form(&req.Mapping, "fieldMapping", "mapping"); err != nil {
+		return req, err
+	}
+	if err := form(&req.ExtraFields, "extraFields"); err != nil {
+		return req, err
+	}
+	if err := form(&req.Rooms, "rooms"); err != nil {
+		return req, err
+	}
+	if len(req.Rooms) == 0 {
+		var distribution map[string]int
+		if err := form(&distribution, "roomDistribution"); err != nil {
+			return req, err
+		}
+		byCapacity := map[int]int{}
+		total := 0
+		for capacity, count := range distribution {
+			n, err := strconv.Atoi(capacity)
+			if err != nil || n < 1 || count < 0 {
+				return req, fmt.Errorf("roomDistribution should map capacities to room counts")
+			}
+			// checked before the rooms are built, as the counts are unbounded
+			if total += count; count > allotment.MaxRooms || total > allotment.MaxRooms {
+				return req, fmt.Errorf("roomDistribution lists more than the %d rooms an allotment takes", allotment.MaxRooms)
+			}
+			byCapacity[n] += count
+		}
+		req.Rooms = allotment.RoomsFromDistribution(byCapacity)
+	}
+	if err := form(&req.Rules, "rules"); err != nil {
+		return req, err
+	}
+	for name, target := range map[string]*string{"gender": &req.Rules.Gender, "soePriority": &req.Rules.SOEPriority, "groupBy": &req.Rules.GroupBy} {
+		if v := c.FormValue(name); v != "" {
+			*target = v
+		}
+	}
+	if priority := splitList(c.FormValue("priority")); len(priority) > 0 {
+		req.Rules.Priority = priority
+	}
+	return req, nil
+}
+
+func readUpload(file *multipart.FileHeader, format, sheet string) ([][]string, error) {
+	f, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return importer.ReadTable(f, format, sheet)
+}
+
+// lookupCGPI takes the CGPI of a student from the result store.
+func lookupCGPI(rollNo string) (float64, bool) {
+	student, ok := resultStore.Get(rollNo)
+	if !ok || student.CGPI == 0 {
+		return 0, false
+	}
+	return student.CGPI, true
+}
+
+// sendAllotment answers with the allotment as JSON, or as a CSV or XLSX
+// download for ?format=csv|xlsx.
+func sendAllotment(c *fiber.Ctx, result allotmentResult) error {
+	format := c.Query("format", "json")
+	if format == "json" {
+		return c.JSON(result)
+	}
+	if format != allotment.FormatCSV && format != allotment.FormatXLSX {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "format should be json, csv or xlsx"})
+	}
+	name := "allotment"
+	if result.ID != "" {
+		name += "-" + result.ID
+		c.Set("X-Allotment-Id", result.ID)
+	}
+	c.Set(fiber.HeaderContentType, allotment.ContentType(format))
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.%s"`, name, format))
+	return allotment.Write(c.Response().BodyWriter(), format, result.Allocation)
+}
+
+func registerAllotmentRoutes(router fiber.Router) {
+	// allot rooms to the students of an uploaded sheet; ?dryRun=true only
+	// reports the outcome, otherwise the allotment is saved for later export
+	allot := func(c *fiber.Ctx) error {
+		req, err := allotmentRequest(c)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		alloc, err := allotment.Allot(req, lookupCGPI)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		result := allotmentResult{CreatedAt: time.Now(), DryRun: c.QueryBool("dryRun"), Allocation: alloc}
+		if !result.DryRun {
+			pruneAllotments(result.CreatedAt)
+			result.ID = uuid.NewString()
+			if err := resultStore.SaveDocument(allotmentDocument(result.ID), result); err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+			}
+		}
+		return sendAllotment(c, result)
+	}
+	router.Post("/hostels/allotment", allot)
+	// the path of the Node server
+	router.Post("/hostels/allotment/rooms-from-excel", allot)
+
+	// a saved allotment; ?format=csv|xlsx downloads it
+	router.Get("/hostels/allotments/:id", func(c *fiber.Ctx) error {
+		var result allotmentResult
+		ok, err := resultStore.LoadDocument(allotmentDocument(c.Params("id")), &result)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		if !ok || result.expired(time.Now()) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "allotment not found"})
+		}
+		return sendAllotment(c, result)
+	})
+
+	// delete a saved allotment; admin only
+	router.Delete("/hostels/allotments/:id", middleware.RequireServerIdentity, func(c *fiber.Ctx) error {
+		name := allotmentDocument(c.Params("id"))
+		var result allotmentResult
+		ok, err := resultStore.LoadDocument(name, &result)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		if !ok {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "allotment not found"})
+		}
+		if err := resultStore.DeleteDocument(name); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+}
@@ -0,0 +1,116 @@
+package routes
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kanakkholwal/go-server/config"
+	"github.com/kanakkholwal/go-server/pkg/analytics"
+)
+
+// minCohortFromQuery reads ?minCohort=, which may raise but never lower the
+// configured suppression threshold.
+func minCohortFromQuery(c *fiber.Ctx) int {
+	minCohort := config.Get().AnalyticsMinCohort
+	if v, err := strconv.Atoi(c.Query("minCohort")); err == nil && v > minCohort {
+		minCohort = v
+	}
+	return minCohort
+}
+
+// analyticsCache is invalidated whenever a new result lands in resultStore.
+var analyticsCache = analytics.NewCache()
+
+// cachedJSON responds with the value cached for this route and query string,
+// computing it when the stored results changed since it was cached.
+func cachedJSON(c *fiber.Ctx, compute func() (any, error)) error {
+	key := c.Path() + "?" + string(c.Request().URI().QueryString())
+	var computeErr error
+	value, hit := analyticsCache.Get(key, resultStore.Version(), func() any {
+		v, err := compute()
+		if err != nil {
+			computeErr = err
+			return nil
+		}
+		return v
+	})
+	if computeErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": computeErr.Error()})
+	}
+	if hit {
+		c.Set("X-Cache", "HIT")
+	} else {
+		c.Set("X-Cache", "MISS")
+	}
+	return c.JSON(value)
+}
+
+func registerAnalyticsRoutes(router fiber.Router) {
+	// CGPI/SGPI statistics of any cohort of stored results
+	// ?batch=2022&branch=cs&programme=btech&top=10&cutoffs=7,8,9&bucket=0.5
+	router.Get("/analytics/cohort", func(c *fiber.Ctx) error {
+		opts := analytics.StatsOptions{TopN: c.QueryInt("top"), BucketWidth: c.QueryFloat("bucket")}
+		if raw := c.Query("cutoffs"); raw != "" {
+			opts.Cutoffs = []float64{}
+			for _, part := range splitList(raw) {
+				v, err := strconv.ParseFloat(part, 64)
+				if err != nil {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "cutoffs should be a comma separated list of numbers"})
+				}
+				opts.Cutoffs = append(opts.Cutoffs, v)
+			}
+		}
+		minCohort := minCohortFromQuery(c)
+		return cachedJSON(c, func() (any, error) {
+			students, err := storedCohort(c)
+			if err != nil {
+				return nil, err
+			}
+			if len(students) < minCohort {
+				return fiber.Map{"students": len(students), "suppressed": true, "minCohort": minCohort}, nil
+			}
+			return analytics.CohortStatistics(students, opts), nil
+		})
+	})
+
+	// courses ordered from toughest to easiest over stored results
+	// ?batch=2022&branch=cs&electives=true&limit=20
+	router.Get("/analytics/courses/difficulty", func(c *fiber.Ctx) error {
+		return cachedJSON(c, func() (any, error) {
+			students, err := storedCohort(c)
+			if err != nil {
+				return nil, err
+			}
+			ranked := analytics.RankCourses(students, minCohortFromQuery(c), c.QueryBool("electives"))
+			if limit := c.QueryInt("limit"); limit > 0 && limit < len(ranked) {
+				ranked = ranked[:limit]
+			}
+			return fiber.Map{"students": len(students), "courses": ranked}, nil
+		})
+	})
+
+	// grade distribution of one course over stored results
+	// ?batch=2022&branch=cs&semester=3&groupBy=batch,branch
+	router.Get("/analytics/courses/:code", func(c *fiber.Ctx) error {
+		groupBy := splitList(c.Query("groupBy"))
+		for _, dim := range groupBy {
+			switch dim {
+			case analytics.GroupBatch, analytics.GroupBranch, analytics.GroupSemester, analytics.GroupProgramme:
+			default:
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "groupBy should be a list of batch, branch, semester and programme"})
+			}
+		}
+		return cachedJSON(c, func() (any, error) {
+			students, err := storedCohort(c)
+			if err != nil {
+				return nil, err
+			}
+			return analytics.CourseDistribution(students, analytics.CourseQuery{
+				Code:      c.Params("code"),
+				Semester:  c.Query("semester"),
+				GroupBy:   groupBy,
+				MinCohort: minCohortFromQuery(c),
+			}), nil
+		})
+	})
+}
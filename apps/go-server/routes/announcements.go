@@ -0,0 +1,113 @@
+package routes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kanakkholwal/go-server/config"
+	"github.com/kanakkholwal/go-server/middleware"
+	"github.com/kanakkholwal/go-server/pkg/announcements"
+	"github.com/kanakkholwal/go-server/pkg/refresher"
+)
+
+// announcementFeed is loaded from the result store by
+// registerAnnouncementRoutes.
+var announcementFeed *announcements.Feed
+
+// announcementsRefreshTimeout bounds one refresh of every announcement page.
+const announcementsRefreshTimeout = 2 * time.Minute
+
+type (
+	announcementList struct {
+		RefreshedAt *time.Time           `json:"refreshedAt,omitempty"`
+		Count       int                  `json:"count"`
+		Items       []announcements.Item `json:"items"`
+	}
+	announcementStatus struct {
+		RefreshedAt *time.Time             `json:"refreshedAt,omitempty"`
+		Count       int                    `json:"count"`
+		Sources     []announcements.Source `json:"sources"`
+	}
+)
+
+// announcementFilter reads ?kind=notice&since=2024-08-01&q=exam&limit=50.
+// since is a date or an RFC 3339 time and selects items first seen after it.
+func announcementFilter(c *fiber.Ctx) (announcements.Filter, error) {
+	filter := announcements.Filter{Kind: c.Query("kind"), Query: c.Query("q"), Limit: c.QueryInt("limit", 50)}
+	if filter.Kind != "" && !slices.Contains(announcementFeed.Kinds(), filter.Kind) {
+		return filter, fmt.Errorf("unknown kind %q", filter.Kind)
+	}
+	if raw := c.Query("since"); raw != "" {
+		since, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			if since, err = time.Parse(time.DateOnly, raw); err != nil {
+				return filter, fmt.Errorf("since should be a date or an RFC 3339 time")
+			}
+		}
+		filter.Since = since
+	}
+	return filter, nil
+}
+
+func registerAnnouncementRoutes(router fiber.Router) {
+	announcementFeed = announcements.New(resultStore, nil, nil)
+	go announcementFeed.Schedule(context.Background(), config.Get().AnnouncementsRefreshInterval, announcementsRefreshTimeout)
+
+	// notices, tenders and events of nith.ac.in, newest first, as JSON or as
+	// an RSS or Atom feed: ?format=rss|atom
+	router.Get("/announcements", func(c *fiber.Ctx) error {
+		filter, err := announcementFilter(c)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		snap := announcementFeed.Snapshot()
+		items := announcementFeed.Items(filter)
+		updated := time.Time{}
+		if snap.RefreshedAt != nil {
+			updated = *snap.RefreshedAt
+			c.Set(fiber.HeaderLastModified, updated.Format(http.TimeFormat))
+		}
+		channel := announcements.Channel{
+			Title:       "NIT Hamirpur announcements",
+			Link:        "https://nith.ac.in",
+			Self:        c.BaseURL() + c.OriginalURL(),
+			Description: "Notices, tenders and events posted on nith.ac.in",
+		}
+		switch c.Query("format", "json") {
+		case "json":
+			return c.JSON(announcementList{RefreshedAt: snap.RefreshedAt, Count: len(items), Items: items})
+		case "rss":
+			c.Set(fiber.HeaderContentType, "application/rss+xml; charset=utf-8")
+			return announcements.WriteRSS(c.Response().BodyWriter(), channel, items, updated)
+		case "atom":
+			c.Set(fiber.HeaderContentType, "application/atom+xml; charset=utf-8")
+			return announcements.WriteAtom(c.Response().BodyWriter(), channel, items, updated)
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "format should be json, rss or atom"})
+	})
+
+	// per page refresh state
+	router.Get("/announcements/status", func(c *fiber.Ctx) error {
+		snap := announcementFeed.Snapshot()
+		return c.JSON(announcementStatus{RefreshedAt: snap.RefreshedAt, Count: len(snap.Items), Sources: snap.Sources})
+	})
+
+	// scrape the announcement pages again; admin only
+	router.Post("/announcements/refresh", middleware.RequireServerIdentity, func(c *fiber.Ctx) error {
+		ctx, cancel := context.WithTimeout(context.Background(), announcementsRefreshTimeout)
+		defer cancel()
+		report, err := announcementFeed.Refresh(ctx)
+		if errors.Is(err, refresher.ErrRefreshing) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+		}
+		if err != nil {
+			return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.JSON(report)
+	})
+}
@@ -0,0 +1,170 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kanakkholwal/go-server/pkg/scrape"
+	"github.com/kanakkholwal/go-server/types"
+	"github.com/kanakkholwal/go-server/utils"
+)
+
+// cohortFromQuery builds a selector from query parameters. List parameters
+// are comma separated:
+//
+//	?batch=2022,2023&programme=btech&branch=cs,ec&from=1&to=60&include=22MCS004&exclude=22BCS013
+//
+// "batchYear" is accepted as an alias of "batch".
+func cohortFromQuery(c *fiber.Ctx) (types.CohortSelector, error) {
+	sel := types.CohortSelector{
+		Programmes: splitList(c.Query("programme")),
+		Branches:   splitList(c.Query("branch")),
+		Include:    splitList(c.Query("include")),
+		Exclude:    splitList(c.Query("exclude")),
+	}
+	batches := c.Query("batch")
+	if batches == "" {
+		batches = c.Query("batchYear")
+	}
+	for _, b := range splitList(batches) {
+		year, err := strconv.Atoi(b)
+		if err != nil {
+			return sel, fmt.Errorf("batch %q should be a valid year in YYYY format", b)
+		}
+		sel.Batches = append(sel.Batches, year)
+	}
+	var err error
+	if sel.SerialFrom, err = queryInt(c, "from"); err != nil {
+		return sel, err
+	}
+	if sel.SerialTo, err = queryInt(c, "to"); err != nil {
+		return sel, err
+	}
+	return sel, nil
+}
+
+func queryInt(c *fiber.Ctx, key string) (int, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return 0, nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("%s should be an integer", key)
+	}
+	return v, nil
+}
+
+func splitList(raw string) []string {
+	out := []string{}
+	for _, part := range strings.Split(raw, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// resolveCohortQuery parses the cohort from the query and expands it into roll
+// numbers. On failure it also returns the HTTP status to respond with.
+func resolveCohortQuery(c *fiber.Ctx) ([]string, int, error) {
+	sel, err := cohortFromQuery(c)
+	if err != nil {
+		return nil, fiber.StatusBadRequest, err
+	}
+	return resolveCohort(sel)
+}
+
+func resolveCohort(sel types.CohortSelector) ([]string, int, error) {
+	rollNumbers, err := utils.ResolveCohort(sel)
+	if err != nil {
+		return nil, fiber.StatusBadRequest, err
+	}
+	if len(rollNumbers) == 0 {
+		return nil, fiber.StatusNotFound, fmt.Errorf("No roll numbers generated for the given cohort")
+	}
+	return rollNumbers, fiber.StatusOK, nil
+}
+
+// cohortScrapeTimeout bounds the live scrape of a cohort route.
+const cohortScrapeTimeout = 60 * time.Second
+
+// scrapeCohort scrapes the given roll numbers with the same limits as the
+// batch routes and returns the students that were found. Roll numbers still
+// unanswered when the deadline hits are returned as missing.
+func scrapeCohort(ctx context.Context, rollNumbers []string) ([]types.StudentHtmlParsed, []string) {
+	ctx, cancel := context.WithTimeout(ctx, cohortScrapeTimeout)
+	defer cancel()
+
+	students := []types.StudentHtmlParsed{}
+	answered := map[string]bool{}
+	for _, res := range scrape.ScrapeInBulk(ctx, rollNumbers, 30, 500*time.Millisecond) {
+		answered[res.RollNumber] = true
+		if res.Data != nil {
+			students = append(students, *res.Data)
+		}
+	}
+	missing := []string{}
+	for _, roll := range rollNumbers {
+		if !answered[roll] {
+			missing = append(missing, roll)
+		}
+	}
+	return students, missing
+}
+
+// liveCohortLimit is the most roll numbers an academic route scrapes live.
+// Larger cohorts are read from the result store, which scrape jobs fill.
+const liveCohortLimit = 10
+
+// academicCohort is the students an academic route reports on.
+type academicCohort struct {
+	students  []types.StudentHtmlParsed
+	requested int
+	// missing is set when a live scrape ran out of time
+	missing []string
+}
+
+// academicCohortQuery returns the students of the cohort query. A list of at
+// most liveCohortLimit roll numbers given through include, without a batch,
+// is scraped live; any other cohort is read from the result store the way
+// storedCohort does. On failure it also returns the HTTP status to respond
+// with.
+func academicCohortQuery(c *fiber.Ctx) (academicCohort, int, error) {
+	sel, err := cohortFromQuery(c)
+	if err != nil {
+		return academicCohort{}, fiber.StatusBadRequest, err
+	}
+	if len(sel.Batches) == 0 && len(sel.Include) > 0 && len(sel.Include) <= liveCohortLimit {
+		rollNumbers, status, err := resolveCohort(sel)
+		if err != nil {
+			return academicCohort{}, status, err
+		}
+		students, missing := scrapeCohort(c.UserContext(), rollNumbers)
+		return academicCohort{students: students, requested: len(rollNumbers), missing: missing}, fiber.StatusOK, nil
+	}
+	students, err := storedCohort(c)
+	if err != nil {
+		return academicCohort{}, fiber.StatusBadRequest, err
+	}
+	return academicCohort{students: students, requested: len(students)}, fiber.StatusOK, nil
+}
+
+// sendCohortScrape answers a cohort route. When the scrape ran out of time it
+// answers 206 with the missing roll numbers, or 504 when none were answered.
+func sendCohortScrape[T any](c *fiber.Ctx, requested int, students []T, missing []string) error {
+	body := fiber.Map{"requested": requested, "count": len(students), "students": students}
+	if len(missing) == 0 {
+		return c.JSON(body)
+	}
+	body["missing"] = missing
+	body["error"] = fmt.Sprintf("%d of %d roll numbers were not scraped within %s", len(missing), requested, cohortScrapeTimeout)
+	if len(missing) == requested {
+		return c.Status(fiber.StatusGatewayTimeout).JSON(body)
+	}
+	return c.Status(fiber.StatusPartialContent).JSON(body)
+}
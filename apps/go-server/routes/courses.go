@@ -0,0 +1,59 @@
+package routes
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kanakkholwal/go-server/pkg/catalog"
+	"github.com/kanakkholwal/go-server/utils"
+)
+
+// courseCatalog is filled from every student scraped by this process.
+var courseCatalog = catalog.New()
+
+func registerCourseRoutes(router fiber.Router) {
+	// search courses by code or name: ?q=data structures&branch=cs&batch=2022
+	router.Get("/courses", func(c *fiber.Ctx) error {
+		branch := c.Query("branch")
+		if branch != "" {
+			name, ok := utils.BranchDisplayName(branch)
+			if !ok {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "unknown branch " + branch})
+			}
+			branch = name
+		}
+		batch := 0
+		if raw := c.Query("batch"); raw != "" {
+			v, err := strconv.Atoi(raw)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "batch should be a valid year in YYYY format"})
+			}
+			batch = v
+		}
+		courses := courseCatalog.Search(c.Query("q"), branch, batch)
+		return c.JSON(fiber.Map{"count": len(courses), "courses": courses})
+	})
+
+	// courses whose credits changed between schemes or batches
+	router.Get("/courses/credit-changes", func(c *fiber.Ctx) error {
+		courses := courseCatalog.CreditChanges()
+		return c.JSON(fiber.Map{"count": len(courses), "courses": courses})
+	})
+
+	router.Get("/courses/:code", func(c *fiber.Ctx) error {
+		course, ok := courseCatalog.Get(c.Params("code"))
+		if !ok {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "course not found"})
+		}
+		return c.JSON(course)
+	})
+
+	// which batches took a course, with the branches, semesters and credits per batch
+	router.Get("/courses/:code/batches", func(c *fiber.Ctx) error {
+		course, ok := courseCatalog.Get(c.Params("code"))
+		if !ok {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "course not found"})
+		}
+		return c.JSON(fiber.Map{"code": course.Code, "name": course.Name, "batches": course.Batches})
+	})
+}
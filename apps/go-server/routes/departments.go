@@ -0,0 +1,112 @@
+package routes
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kanakkholwal/go-server/constants"
+	"github.com/kanakkholwal/go-server/utils"
+)
+
+// departmentInfo is a department of constants.DepartmentsList together with
+// what is derived from the stored data.
+type departmentInfo struct {
+	constants.Department
+	Stats departmentStats `json:"stats"`
+}
+
+type departmentStats struct {
+	// Students counts stored results by the department in their roll number,
+	// i.e. the branch students were admitted to.
+	Students           int          `json:"students"`
+	StudentsPerBatch   []batchCount `json:"studentsPerBatch"`
+	Faculty            int          `json:"faculty"`
+	FacultyRefreshedAt *time.Time   `json:"facultyRefreshedAt,omitempty"`
+}
+
+type batchCount struct {
+	Batch    int `json:"batch"`
+	Students int `json:"students"`
+}
+
+type departmentList struct {
+	Count       int              `json:"count"`
+	Departments []departmentInfo `json:"departments"`
+}
+
+// departmentStudents counts the stored students of every department code per
+// batch, cached until the store changes.
+func departmentStudents() map[string]map[int]int {
+	value, _ := analyticsCache.Get("departments:students", resultStore.Version(), func() any {
+		counts := map[string]map[int]int{}
+		for _, student := range resultStore.Select(nil) {
+			department, ok := utils.DepartmentForRollNumber(student.RollNumber)
+			if !ok {
+				continue
+			}
+			if counts[department.Code] == nil {
+				counts[department.Code] = map[int]int{}
+			}
+			counts[department.Code][student.Batch]++
+		}
+		return counts
+	})
+	return value.(map[string]map[int]int)
+}
+
+func describeDepartment(department constants.Department, students map[string]map[int]int) departmentInfo {
+	info := departmentInfo{
+		Department: department,
+		Stats:      departmentStats{StudentsPerBatch: []batchCount{}},
+	}
+	for batch, count := range students[department.Code] {
+		info.Stats.Students += count
+		info.Stats.StudentsPerBatch = append(info.Stats.StudentsPerBatch, batchCount{Batch: batch, Students: count})
+	}
+	sort.Slice(info.Stats.StudentsPerBatch, func(i, j int) bool {
+		return info.Stats.StudentsPerBatch[i].Batch < info.Stats.StudentsPerBatch[j].Batch
+	})
+	if facultyDirectory != nil {
+		info.Stats.Faculty = len(facultyDirectory.Department(department.Code))
+		info.Stats.FacultyRefreshedAt = departmentRefreshedAt(facultyDirectory.Snapshot(), department.Code)
+	}
+	return info
+}
+
+func registerDepartmentRoutes(router fiber.Router) {
+	router.Get("/departments", func(c *fiber.Ctx) error {
+		students := departmentStudents()
+		list := departmentList{Count: len(constants.DepartmentsList), Departments: []departmentInfo{}}
+		for _, department := range constants.DepartmentsList {
+			list.Departments = append(list.Departments, describeDepartment(department, students))
+		}
+		return c.JSON(list)
+	})
+
+	// the department a roll number was admitted to, e.g. 22BCS001
+	router.Get("/departments/by-roll/:rollNo", func(c *fiber.Ctx) error {
+		department, ok := utils.DepartmentForRollNumber(c.Params("rollNo"))
+		if !ok {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "no department for roll number " + c.Params("rollNo")})
+		}
+		return c.JSON(describeDepartment(department, departmentStudents()))
+	})
+
+	// the department offering a course, from its code or prefix, e.g. CS-201 or CS
+	router.Get("/departments/by-course/:code", func(c *fiber.Ctx) error {
+		department, ok := utils.DepartmentForCoursePrefix(c.Params("code"))
+		if !ok {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "no department for course " + c.Params("code")})
+		}
+		return c.JSON(describeDepartment(department, departmentStudents()))
+	})
+
+	router.Get("/departments/:code", func(c *fiber.Ctx) error {
+		department, ok := utils.DepartmentByCode(c.Params("code"))
+		if !ok {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "department not found"})
+		}
+		return c.JSON(describeDepartment(department, departmentStudents()))
+	})
+}
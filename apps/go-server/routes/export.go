@@ -0,0 +1,59 @@
+package routes
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kanakkholwal/go-server/pkg/export"
+	"github.com/kanakkholwal/go-server/types"
+)
+
+// exportOptions reads the export options from the query:
+//
+//	?format=xlsx&rows=courses&columns=rollNo:Roll Number,name,cgpi
+func exportOptions(c *fiber.Ctx) export.Options {
+	return export.Options{
+		Format:  c.Query("format", export.FormatCSV),
+		Rows:    c.Query("rows", export.RowsStudents),
+		Columns: splitList(c.Query("columns")),
+	}
+}
+
+// sendExport streams the students to the client as a file download.
+func sendExport(c *fiber.Ctx, students []types.StudentHtmlParsed, name string) error {
+	exporter, err := export.Prepare(students, exportOptions(c))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	c.Set(fiber.HeaderContentType, exporter.ContentType())
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, exporter.Filename(name)))
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		// the status line is already sent, so a failure can only cut the file short
+		if err := exporter.Write(w); err != nil {
+			log.Printf("export %s: %v", name, err)
+		}
+		w.Flush()
+	})
+	return nil
+}
+
+func registerExportRoutes(router fiber.Router) {
+	// export stored results of a cohort (same query parameters as /analytics/cohort)
+	router.Get("/export", func(c *fiber.Ctx) error {
+		students, err := storedCohort(c)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		return sendExport(c, students, "results")
+	})
+	// export the students found by a scrape job
+	router.Get("/scrape-jobs/:id/export", func(c *fiber.Ctx) error {
+		job, ok := scrapeJobs.Get(c.Params("id"))
+		if !ok {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "scrape job not found"})
+		}
+		return sendExport(c, job.Students(), "scrape-job-"+job.Summary().ID)
+	})
+}
@@ -0,0 +1,116 @@
+package routes
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kanakkholwal/go-server/config"
+	"github.com/kanakkholwal/go-server/middleware"
+	"github.com/kanakkholwal/go-server/pkg/faculty"
+	"github.com/kanakkholwal/go-server/pkg/refresher"
+	"github.com/kanakkholwal/go-server/pkg/scrape"
+	"github.com/kanakkholwal/go-server/utils"
+)
+
+// facultyDirectory is loaded from the result store by registerFacultyRoutes.
+var facultyDirectory *faculty.Directory
+
+// facultyRefreshTimeout bounds one refresh of every department page.
+const facultyRefreshTimeout = 2 * time.Minute
+
+func registerFacultyRoutes(router fiber.Router) {
+	facultyDirectory = faculty.New(resultStore, nil)
+	go facultyDirectory.Schedule(context.Background(), config.Get().FacultyRefreshInterval, facultyRefreshTimeout)
+
+	router.Get("/faculties", func(c *fiber.Ctx) error {
+		snap := facultyDirectory.Snapshot()
+		return c.JSON(facultyList{RefreshedAt: snap.RefreshedAt, Count: len(snap.Faculty), Faculty: snap.Faculty})
+	})
+
+	// fuzzy search by name or research area: ?q=sharma&department=cse&limit=10
+	router.Get("/faculties/search", func(c *fiber.Ctx) error {
+		if c.Query("q") == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "q query parameter is required"})
+		}
+		if code := c.Query("department"); code != "" {
+			if _, ok := utils.DepartmentByCode(code); !ok {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "unknown department " + code})
+			}
+		}
+		matches := facultyDirectory.Search(c.Query("q"), c.Query("department"), c.QueryInt("limit", 20))
+		return c.JSON(facultySearch{Count: len(matches), Results: matches})
+	})
+
+	router.Get("/faculties/search/:email", func(c *fiber.Ctx) error {
+		email := c.Params("email")
+		if _, err := mail.ParseAddress(email); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid email"})
+		}
+		f, ok := facultyDirectory.ByEmail(email)
+		if !ok {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Faculty not found"})
+		}
+		return c.JSON(f)
+	})
+
+	// per department refresh state and what the latest refresh changed
+	router.Get("/faculties/status", func(c *fiber.Ctx) error {
+		snap := facultyDirectory.Snapshot()
+		return c.JSON(facultyStatus{RefreshedAt: snap.RefreshedAt, Count: len(snap.Faculty), Departments: snap.Departments, LastDiff: snap.LastDiff})
+	})
+
+	// scrape the department pages again; admin only
+	router.Post("/faculties/refresh", middleware.RequireServerIdentity, func(c *fiber.Ctx) error {
+		ctx, cancel := context.WithTimeout(context.Background(), facultyRefreshTimeout)
+		defer cancel()
+		report, err := facultyDirectory.Refresh(ctx)
+		if errors.Is(err, refresher.ErrRefreshing) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+		}
+		if err != nil {
+			return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.JSON(report)
+	})
+
+	router.Get("/faculties/:departmentCode", func(c *fiber.Ctx) error {
+		department, ok := utils.DepartmentByCode(c.Params("departmentCode"))
+		if !ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid department"})
+		}
+		snap := facultyDirectory.Snapshot()
+		list := facultyDirectory.Department(department.Code)
+		return c.JSON(facultyList{RefreshedAt: departmentRefreshedAt(snap, department.Code), Department: department.Code, Count: len(list), Faculty: list})
+	})
+}
+
+type (
+	facultyList struct {
+		RefreshedAt *time.Time       `json:"refreshedAt,omitempty"`
+		Department  string           `json:"department,omitempty"`
+		Count       int              `json:"count"`
+		Faculty     []scrape.Faculty `json:"faculty"`
+	}
+	facultySearch struct {
+		Count   int             `json:"count"`
+		Results []faculty.Match `json:"results"`
+	}
+	facultyStatus struct {
+		RefreshedAt *time.Time           `json:"refreshedAt,omitempty"`
+		Count       int                  `json:"count"`
+		Departments []faculty.Department `json:"departments"`
+		LastDiff    *faculty.Diff        `json:"lastDiff,omitempty"`
+	}
+)
+
+func departmentRefreshedAt(snap faculty.Snapshot, code string) *time.Time {
+	for _, dept := range snap.Departments {
+		if dept.Code == code {
+			return dept.RefreshedAt
+		}
+	}
+	return nil
+}
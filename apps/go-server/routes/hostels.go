@@ -0,0 +1,46 @@
+package routes
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kanakkholwal/go-server/config"
+	"github.com/kanakkholwal/go-server/pkg/scrape"
+)
+
+// hostelPage is the cached hostel management page, set up by
+// registerHostelRoutes.
+var hostelPage *pageCache[*scrape.HostelDirectory]
+
+type hostelList struct {
+	FetchedAt time.Time `json:"fetchedAt"`
+	*scrape.HostelDirectory
+}
+
+func registerHostelRoutes(router fiber.Router) {
+	hostelPage = &pageCache[*scrape.HostelDirectory]{ttl: config.Get().HostelsCacheTTL, fetch: scrape.GetHostels}
+
+	// hostel in-charges and every hostel's functionaries; ?fresh=true refetches the page
+	router.Get("/hostels", func(c *fiber.Ctx) error {
+		dir, fetchedAt, status, err := hostelPage.get(c.QueryBool("fresh"))
+		if err != nil {
+			return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": err.Error()})
+		}
+		setPageHeaders(c, fetchedAt, status)
+		return c.JSON(hostelList{FetchedAt: fetchedAt, HostelDirectory: dir})
+	})
+
+	router.Get("/hostels/:slug", func(c *fiber.Ctx) error {
+		dir, fetchedAt, status, err := hostelPage.get(false)
+		if err != nil {
+			return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": err.Error()})
+		}
+		for _, hostel := range dir.Hostels {
+			if hostel.Slug == c.Params("slug") {
+				setPageHeaders(c, fetchedAt, status)
+				return c.JSON(hostel)
+			}
+		}
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "hostel not found"})
+	})
+}
@@ -0,0 +1,102 @@
+package routes
+
+import (
+	"bytes"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kanakkholwal/go-server/middleware"
+	"github.com/kanakkholwal/go-server/pkg/importer"
+	"github.com/kanakkholwal/go-server/pkg/store"
+	"github.com/kanakkholwal/go-server/types"
+)
+
+// importTarget upserts imported records into the result store and indexes
+// their courses, as happens for scraped results.
+type importTarget struct {
+	store.Store
+}
+
+func (t importTarget) Upsert(student *types.StudentHtmlParsed) {
+	t.Store.Upsert(student)
+	courseCatalog.Observe(student)
+}
+
+// importFormat picks the input format from ?format, the uploaded file name or
+// the content type, in that order.
+func importFormat(c *fiber.Ctx, filename string) string {
+	if format := strings.ToLower(c.Query("format")); format != "" {
+		return format
+	}
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		return importer.FormatJSON
+	case ".ndjson", ".jsonl":
+		return importer.FormatNDJSON
+	case ".csv":
+		return importer.FormatCSV
+	case ".xlsx":
+		return importer.FormatXLSX
+	}
+	contentType := strings.ToLower(c.Get(fiber.HeaderContentType))
+	switch {
+	case strings.Contains(contentType, "ndjson"), strings.Contains(contentType, "jsonl"):
+		return importer.FormatNDJSON
+	case strings.Contains(contentType, "json"):
+		return importer.FormatJSON
+	case strings.Contains(contentType, "csv"):
+		return importer.FormatCSV
+	case strings.Contains(contentType, "spreadsheetml"):
+		return importer.FormatXLSX
+	}
+	return ""
+}
+
+func registerImportRoutes(router fiber.Router) {
+	// import a results dump or a freshers list, either as the request body or
+	// as a multipart "file" upload:
+	//
+	//	?format=csv&map=rollNo:Roll Number,name:3&sheet=Sheet1&strict=true&dryRun=true
+	//
+	// admin only, as it overwrites stored results
+	router.Post("/import", middleware.RequireServerIdentity, func(c *fiber.Ctx) error {
+		var body io.Reader
+		filename := ""
+		if file, err := c.FormFile("file"); err == nil {
+			f, err := file.Open()
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+			}
+			defer f.Close()
+			body, filename = f, file.Filename
+		} else {
+			body = bytes.NewReader(c.Body())
+		}
+
+		opts := importer.Options{
+			Format:  importFormat(c, filename),
+			Mapping: map[string]string{},
+			Sheet:   c.Query("sheet"),
+			Strict:  c.QueryBool("strict"),
+			DryRun:  c.QueryBool("dryRun"),
+		}
+		if opts.Format == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "format query parameter is required (json, ndjson, csv or xlsx)"})
+		}
+		for _, pair := range splitList(c.Query("map")) {
+			field, column, ok := strings.Cut(pair, ":")
+			if !ok {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "map entries should look like field:column"})
+			}
+			opts.Mapping[strings.TrimSpace(field)] = strings.TrimSpace(column)
+		}
+
+		report, err := importer.Run(body, opts, importTarget{resultStore})
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.JSON(report)
+	})
+}
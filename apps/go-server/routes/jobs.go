@@ -0,0 +1,93 @@
+package routes
+
+import (
+	"sort"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kanakkholwal/go-server/middleware"
+	"github.com/kanakkholwal/go-server/pkg/jobs"
+	"github.com/kanakkholwal/go-server/types"
+)
+
+var scrapeJobs = jobs.NewManager()
+
+type abnormalEntry struct {
+	RollNumber   string              `json:"rollNo"`
+	Name         string              `json:"name"`
+	Branch       string              `json:"branch"`
+	Batch        int                 `json:"batch"`
+	Abnormal     bool                `json:"abnormal"`
+	QualityFlags []types.QualityFlag `json:"qualityFlags"`
+}
+
+func registerJobRoutes(router fiber.Router) {
+	// start a background scrape of a cohort; admin only, as a job scrapes
+	// the result portal for hours
+	router.Post("/scrape-jobs", middleware.RequireServerIdentity, func(c *fiber.Ctx) error {
+		var sel types.CohortSelector
+		if err := c.BodyParser(&sel); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid cohort selector"})
+		}
+		rollNumbers, status, err := resolveCohort(sel)
+		if err != nil {
+			return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+		}
+		job, err := scrapeJobs.Start(sel, rollNumbers)
+		if err != nil {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusAccepted).JSON(job.Summary())
+	})
+
+	router.Get("/scrape-jobs", func(c *fiber.Ctx) error {
+		return c.JSON(scrapeJobs.List())
+	})
+
+	// job progress; ?results=true also returns the results collected so far
+	router.Get("/scrape-jobs/:id", func(c *fiber.Ctx) error {
+		job, ok := scrapeJobs.Get(c.Params("id"))
+		if !ok {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "scrape job not found"})
+		}
+		if c.QueryBool("results") {
+			return c.JSON(fiber.Map{"job": job.Summary(), "results": job.Results()})
+		}
+		return c.JSON(job.Summary())
+	})
+
+	// cancel a job; admin only, like starting one
+	router.Delete("/scrape-jobs/:id", middleware.RequireServerIdentity, func(c *fiber.Ctx) error {
+		job, ok := scrapeJobs.Get(c.Params("id"))
+		if !ok {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "scrape job not found"})
+		}
+		job.Cancel()
+		return c.JSON(job.Summary())
+	})
+
+	// records of a job that failed validation; ?warnings=true also lists
+	// records that only carry warnings
+	router.Get("/scrape-jobs/:id/abnormal", func(c *fiber.Ctx) error {
+		job, ok := scrapeJobs.Get(c.Params("id"))
+		if !ok {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "scrape job not found"})
+		}
+		withWarnings := c.QueryBool("warnings")
+		entries := []abnormalEntry{}
+		for _, student := range job.Students() {
+			if !student.Abnormal && (!withWarnings || len(student.QualityFlags) == 0) {
+				continue
+			}
+			entries = append(entries, abnormalEntry{
+				RollNumber:   student.RollNumber,
+				Name:         student.Name,
+				Branch:       student.Branch,
+				Batch:        student.Batch,
+				Abnormal:     student.Abnormal,
+				QualityFlags: student.QualityFlags,
+			})
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].RollNumber < entries[j].RollNumber })
+		return c.JSON(fiber.Map{"job": job.Summary(), "count": len(entries), "records": entries})
+	})
+}
@@ -0,0 +1,348 @@
+package routes
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kanakkholwal/go-server/pkg/allotment"
+	"github.com/kanakkholwal/go-server/pkg/analytics"
+	"github.com/kanakkholwal/go-server/pkg/announcements"
+	v1 "github.com/kanakkholwal/go-server/pkg/api/v1"
+	"github.com/kanakkholwal/go-server/pkg/catalog"
+	"github.com/kanakkholwal/go-server/pkg/faculty"
+	"github.com/kanakkholwal/go-server/pkg/grades"
+	"github.com/kanakkholwal/go-server/pkg/importer"
+	"github.com/kanakkholwal/go-server/pkg/jobs"
+	"github.com/kanakkholwal/go-server/pkg/openapi"
+	"github.com/kanakkholwal/go-server/pkg/planner"
+	"github.com/kanakkholwal/go-server/pkg/scrape"
+	"github.com/kanakkholwal/go-server/pkg/store"
+	"github.com/kanakkholwal/go-server/types"
+	"github.com/kanakkholwal/go-server/utils"
+)
+
+// Response shapes of routes that answer with a fiber.Map, for the document.
+type (
+	cohortRollNumbers struct {
+		Count       int      `json:"count"`
+		RollNumbers []string `json:"rollNumbers"`
+	}
+	backlogList struct {
+		Requested int            `json:"requested"`
+		Count     int            `json:"count"`
+		Students  []backlogEntry `json:"students"`
+		// Missing and Error are set on a 206 or 504 answer, when a live
+		// scrape ran out of time
+		Missing []string `json:"missing,omitempty"`
+		Error   string   `json:"error,omitempty"`
+	}
+	branchChangeList struct {
+		Requested int                 `json:"requested"`
+		Count     int                 `json:"count"`
+		Students  []branchChangeEntry `json:"students"`
+		// Missing and Error are set on a 206 or 504 answer, when a live
+		// scrape ran out of time
+		Missing []string `json:"missing,omitempty"`
+		Error   string   `json:"error,omitempty"`
+	}
+	courseList struct {
+		Count   int              `json:"count"`
+		Courses []catalog.Course `json:"courses"`
+	}
+	courseBatches struct {
+		Code    string               `json:"code"`
+		Name    string               `json:"name"`
+		Batches []catalog.BatchUsage `json:"batches"`
+	}
+	jobResults struct {
+		Job     jobs.Summary          `json:"job"`
+		Results []scrape.ScrapeResult `json:"results"`
+	}
+	abnormalList struct {
+		Job     jobs.Summary    `json:"job"`
+		Count   int             `json:"count"`
+		Records []abnormalEntry `json:"records"`
+	}
+	courseRanking struct {
+		Students int                          `json:"students"`
+		Courses  []analytics.CourseDifficulty `json:"courses"`
+	}
+)
+
+func cohortParams(batchRequired bool) []openapi.Param {
+	return []openapi.Param{
+		{Name: "batch", Description: "Comma separated batch years", Required: batchRequired},
+		{Name: "programme", Description: "Comma separated programmes, e.g. btech,dual"},
+		{Name: "branch", Description: "Comma separated branch codes, e.g. cs,ec"},
+		{Name: "from", Type: "integer", Description: "First roll serial", Minimum: openapi.Num(0), Maximum: openapi.Num(utils.MaxRollSerial)},
+		{Name: "to", Type: "integer", Description: "Last roll serial", Minimum: openapi.Num(0), Maximum: openapi.Num(utils.MaxRollSerial)},
+		{Name: "include", Description: "Comma separated roll numbers to add"},
+		{Name: "exclude", Description: "Comma separated roll numbers to leave out"},
+	}
+}
+
+func withParams(base []openapi.Param, extra ...openapi.Param) []openapi.Param {
+	return append(base, extra...)
+}
+
+var (
+	minCohortParam = openapi.Param{Name: "minCohort", Type: "integer", Description: "Smallest group to report figures for", Minimum: openapi.Num(1)}
+	cacheParams    = []openapi.Param{
+		{Name: "rollNo", Description: "Roll number", Required: true},
+		{Name: "fresh", Type: "boolean", Description: "Skip the result cache"},
+		{Name: "maxAge", Type: "integer", Description: "Oldest cached result accepted, in seconds", Minimum: openapi.Num(0)},
+		{Name: "staleWhileRevalidate", Type: "integer", Description: "Seconds past maxAge a stale result is served while it is refetched", Minimum: openapi.Num(0)},
+	}
+	storeQueryParams = []openapi.Param{
+		{Name: "batch", Description: "Comma separated batch years"},
+		{Name: "branch", Description: "Comma separated branch codes or names"},
+		{Name: "programme", Description: "Comma separated programmes"},
+		{Name: "minCgpi", Type: "number", Minimum: openapi.Num(0), Maximum: openapi.Num(10)},
+		{Name: "maxCgpi", Type: "number", Minimum: openapi.Num(0), Maximum: openapi.Num(10)},
+		{Name: "q", Description: "Name or roll number substring"},
+		{Name: "sort", Description: "rollNo, name, cgpi or batch, prefixed with - for descending"},
+		{Name: "page", Type: "integer", Minimum: openapi.Num(1)},
+		{Name: "limit", Type: "integer", Minimum: openapi.Num(1), Maximum: openapi.Num(store.MaxLimit)},
+	}
+	exportParams = []openapi.Param{
+		{Name: "format", Enum: []string{"csv", "xlsx", "parquet"}},
+		{Name: "rows", Enum: []string{"students", "courses"}},
+		{Name: "columns", Description: "Comma separated column keys, each optionally renamed with key:Header"},
+	}
+	allotmentFormatParam = openapi.Param{Name: "format", Enum: []string{"json", allotment.FormatCSV, allotment.FormatXLSX}, Description: "JSON, or a CSV or XLSX download"}
+	badRequest           = map[string]string{"400": "Invalid request"}
+	cohortScrapeErrors   = map[string]string{"400": "Invalid request", "504": "No roll number was scraped in time; a partial scrape answers 206 with the missing roll numbers"}
+	notFound             = map[string]string{"404": "Not found"}
+)
+
+// apiRoutes documents every route under /api. The OpenAPI document, the
+// request validation and the generated client in pkg/client all come from
+// this table, so a route added to the router should be added here too.
+var apiRoutes = []openapi.Route{
+	{Method: "GET", Path: "/api/scrape", ID: "scrape", Tag: "results", Summary: "Fetch the result of a roll number through the result cache",
+		Query:    withParams(cacheParams, openapi.Param{Name: "standing", Type: "boolean", Description: "Add the position among stored results"}),
+		Response: types.StudentHtmlParsed{}, Errors: map[string]string{"400": "Missing roll number", "500": "The result portal failed"}},
+	{Method: "GET", Path: "/api/generate-roll-numbers", ID: "generateRollNumbers", Tag: "cohorts", Summary: "List the roll numbers of a cohort",
+		Query: cohortParams(false), Response: []string{}, Errors: badRequest},
+	{Method: "POST", Path: "/api/cohort", ID: "resolveCohort", Tag: "cohorts", Summary: "Resolve a cohort selector to roll numbers",
+		Body: types.CohortSelector{}, Response: cohortRollNumbers{}, Errors: badRequest},
+	{Method: "POST", Path: "/api/bulk-scrape", ID: "bulkScrape", Tag: "results", Summary: "Fetch the results of several roll numbers",
+		Body: BulkRequest{}, BodyRequired: []string{"rollNumbers"}, Response: []scrape.ScrapeResult{}, Errors: badRequest},
+	{Method: "POST", Path: "/api/scrape-batch", ID: "scrapeBatch", Tag: "results", Summary: "Fetch the results of a batch",
+		Query:    withParams(cohortParams(false), openapi.Param{Name: "batchYear", Required: true, Description: "Comma separated batch years"}),
+		Response: []scrape.ScrapeResult{}, Errors: badRequest},
+	{Method: "GET", Path: "/api/scrape-class", ID: "scrapeClass", Tag: "results", Summary: "Fetch the results of one branch of one programme in a batch",
+		Query:    []openapi.Param{{Name: "batch", Required: true}, {Name: "branch", Required: true}, {Name: "programme", Required: true}},
+		Response: []scrape.ScrapeResult{}, Errors: badRequest},
+
+	{Method: "GET", Path: "/api/grade-scales", ID: "gradeScales", Tag: "academic", Summary: "Grade scales in use",
+		Query: []openapi.Param{{Name: "scheme", Description: "Return only the scale applied to this scheme"}}, Response: []grades.Scale{}},
+	{Method: "GET", Path: "/api/backlogs", ID: "backlogs", Tag: "academic", Summary: "Students of a cohort by academic status, from stored results; up to 10 roll numbers given through include alone are scraped live, answering 206 with the missing ones when the scrape runs out of time",
+		Query:    withParams(cohortParams(false), openapi.Param{Name: "status", Enum: []string{types.StatusClear, types.StatusActiveBacklog, types.StatusClearedBacklog, "any_backlog"}}),
+		Response: backlogList{}, Errors: cohortScrapeErrors},
+	{Method: "GET", Path: "/api/branch-changes", ID: "branchChanges", Tag: "academic", Summary: "Students of a cohort whose courses show a branch change, from stored results; up to 10 roll numbers given through include alone are scraped live, answering 206 with the missing ones when the scrape runs out of time",
+		Query:    withParams(cohortParams(false), openapi.Param{Name: "minConfidence", Type: "number", Minimum: openapi.Num(0), Maximum: openapi.Num(1)}),
+		Response: branchChangeList{}, Errors: cohortScrapeErrors},
+
+	{Method: "POST", Path: "/api/scrape-jobs", ID: "startScrapeJob", Tag: "jobs", Summary: "Start a background scrape of a cohort; needs the server identity",
+		Body: types.CohortSelector{}, Status: "202", Response: jobs.Summary{},
+		Errors: map[string]string{"400": "Invalid request", "403": "Missing server identity", "429": "Too many scrape jobs are running"}},
+	{Method: "GET", Path: "/api/scrape-jobs", ID: "listScrapeJobs", Tag: "jobs", Summary: "List scrape jobs", Response: []jobs.Summary{}},
+	{Method: "GET", Path: "/api/scrape-jobs/:id", ID: "getScrapeJob", Tag: "jobs", Summary: "Progress of a scrape job",
+		Query: []openapi.Param{{Name: "results", Type: "boolean", Description: "Also return the results collected so far, as {job, results}"}}, Response: jobs.Summary{}, Errors: notFound},
+	{Method: "DELETE", Path: "/api/scrape-jobs/:id", ID: "cancelScrapeJob", Tag: "jobs", Summary: "Cancel a scrape job; needs the server identity",
+		Response: jobs.Summary{}, Errors: map[string]string{"403": "Missing server identity", "404": "Not found"}},
+	{Method: "GET", Path: "/api/scrape-jobs/:id/abnormal", ID: "scrapeJobAbnormal", Tag: "jobs", Summary: "Records of a job that failed validation",
+		Query: []openapi.Param{{Name: "warnings", Type: "boolean", Description: "Also list records with warnings only"}}, Response: abnormalList{}, Errors: notFound},
+	{Method: "GET", Path: "/api/scrape-jobs/:id/export", ID: "exportScrapeJob", Tag: "export", Summary: "Download the results of a scrape job",
+		Query: exportParams, ContentType: "application/octet-stream", Errors: map[string]string{"400": "Invalid export options", "404": "Not found"}},
+
+	{Method: "GET", Path: "/api/courses", ID: "searchCourses", Tag: "courses", Summary: "Search the course catalogue",
+		Query: []openapi.Param{{Name: "q"}, {Name: "branch"}, {Name: "batch", Type: "integer"}}, Response: courseList{}, Errors: badRequest},
+	{Method: "GET", Path: "/api/courses/credit-changes", ID: "courseCreditChanges", Tag: "courses", Summary: "Courses whose credits changed between schemes or batches", Response: courseList{}},
+	{Method: "GET", Path: "/api/courses/:code", ID: "getCourse", Tag: "courses", Summary: "One course of the catalogue", Response: catalog.Course{}, Errors: notFound},
+	{Method: "GET", Path: "/api/courses/:code/batches", ID: "getCourseBatches", Tag: "courses", Summary: "Who took a course in each batch", Response: courseBatches{}, Errors: notFound},
+
+	{Method: "GET", Path: "/api/analytics/cohort", ID: "cohortStatistics", Tag: "analytics", Summary: "CGPI and SGPI statistics of stored results",
+		Query: withParams(cohortParams(false), minCohortParam,
+			openapi.Param{Name: "top", Type: "integer", Minimum: openapi.Num(0)},
+			openapi.Param{Name: "cutoffs", Description: "Comma separated CGPI cutoffs"},
+			openapi.Param{Name: "bucket", Type: "number", Description: "Histogram bucket width"}),
+		Response: analytics.CohortStats{}, Errors: badRequest},
+	{Method: "GET", Path: "/api/analytics/courses/difficulty", ID: "courseDifficulty", Tag: "analytics", Summary: "Courses from toughest to easiest",
+		Query: withParams(cohortParams(false), minCohortParam,
+			openapi.Param{Name: "electives", Type: "boolean"},
+			openapi.Param{Name: "limit", Type: "integer", Minimum: openapi.Num(0)}),
+		Response: courseRanking{}, Errors: badRequest},
+	{Method: "GET", Path: "/api/analytics/courses/:code", ID: "courseDistribution", Tag: "analytics", Summary: "Grade distribution of one course",
+		Query: withParams(cohortParams(false), minCohortParam,
+			openapi.Param{Name: "semester"},
+			openapi.Param{Name: "groupBy", Description: "Comma separated list of batch, branch, semester and programme"}),
+		Response: analytics.CourseReport{}, Errors: badRequest},
+
+	{Method: "GET", Path: "/api/results", ID: "listResults", Tag: "store", Summary: "Search stored results",
+		Query: storeQueryParams, Response: store.Page{}, Errors: badRequest},
+	{Method: "GET", Path: "/api/results/:rollNo/history", ID: "resultHistory", Tag: "store", Summary: "Every stored version of a result", Response: []store.Revision{}, Errors: notFound},
+	{Method: "GET", Path: "/api/results/:rollNo", ID: "getResult", Tag: "store", Summary: "Stored result of a roll number",
+		Query: []openapi.Param{{Name: "standing", Type: "boolean"}}, Response: types.StudentHtmlParsed{}, Errors: notFound},
+	{Method: "POST", Path: "/api/planner", ID: "planCGPI", Tag: "planner", Summary: "Project a CGPI under grade changes and future semesters",
+		Body: planner.Request{}, Response: planner.Plan{}, Errors: map[string]string{"400": "Invalid request", "404": "No stored result"}},
+	{Method: "GET", Path: "/api/export", ID: "exportResults", Tag: "export", Summary: "Download stored results of a cohort",
+		Query: withParams(cohortParams(false), exportParams...), ContentType: "application/octet-stream", Errors: badRequest},
+	{Method: "POST", Path: "/api/import", ID: "importResults", Tag: "import", Summary: "Import a results dump or a freshers list, as the body or a multipart file; needs the server identity",
+		Query: []openapi.Param{
+			{Name: "format", Enum: []string{importer.FormatJSON, importer.FormatNDJSON, importer.FormatCSV, importer.FormatXLSX}},
+			{Name: "map", Description: "Column mapping, e.g. rollNo:Roll Number,name:3"},
+			{Name: "sheet"},
+			{Name: "strict", Type: "boolean"},
+			{Name: "dryRun", Type: "boolean"},
+		},
+		Upload:   []string{"application/json", "application/x-ndjson", "text/csv", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "multipart/form-data"},
+		Response: importer.Report{}, Errors: map[string]string{"400": "Invalid request", "403": "Missing server identity"}},
+
+	{Method: "GET", Path: "/api/faculties", ID: "listFaculty", Tag: "faculty", Summary: "Faculty of every department", Response: facultyList{}},
+	{Method: "GET", Path: "/api/faculties/search", ID: "searchFaculty", Tag: "faculty", Summary: "Fuzzy search of faculty by name or research area",
+		Query: []openapi.Param{
+			{Name: "q", Required: true, Description: "Name or research area, typos allowed"},
+			{Name: "department", Description: "Department code, e.g. cse"},
+			{Name: "limit", Type: "integer", Minimum: openapi.Num(0)},
+		},
+		Response: facultySearch{}, Errors: badRequest},
+	{Method: "GET", Path: "/api/faculties/search/:email", ID: "getFacultyByEmail", Tag: "faculty", Summary: "Faculty member with an email",
+		Response: scrape.Faculty{}, Errors: map[string]string{"400": "Invalid email", "404": "Not found"}},
+	{Method: "GET", Path: "/api/faculties/status", ID: "facultyStatus", Tag: "faculty", Summary: "Refresh state per department and the changes of the latest refresh", Response: facultyStatus{}},
+	{Method: "POST", Path: "/api/faculties/refresh", ID: "refreshFaculty", Tag: "faculty", Summary: "Scrape the department pages again; needs the server identity",
+		Response: faculty.RefreshReport{}, Errors: map[string]string{"403": "Missing server identity", "409": "A refresh is already running", "502": "Every department page failed"}},
+	{Method: "GET", Path: "/api/faculties/:departmentCode", ID: "listDepartmentFaculty", Tag: "faculty", Summary: "Faculty of one department",
+		Response: facultyList{}, Errors: map[string]string{"400": "Invalid department"}},
+
+	{Method: "GET", Path: "/api/departments", ID: "listDepartments", Tag: "departments", Summary: "Every department with its programmes, student and faculty counts", Response: departmentList{}},
+	{Method: "GET", Path: "/api/departments/by-roll/:rollNo", ID: "departmentForRollNumber", Tag: "departments", Summary: "Department a roll number was admitted to", Response: departmentInfo{}, Errors: notFound},
+	{Method: "GET", Path: "/api/departments/by-course/:code", ID: "departmentForCourse", Tag: "departments", Summary: "Department offering a course, from its code or prefix", Response: departmentInfo{}, Errors: notFound},
+	{Method: "GET", Path: "/api/departments/:code", ID: "getDepartment", Tag: "departments", Summary: "One department by code", Response: departmentInfo{}, Errors: notFound},
+
+	{Method: "GET", Path: "/api/hostels", ID: "listHostels", Tag: "hostels", Summary: "Hostel in-charges and the functionaries of every hostel",
+		Query: []openapi.Param{{Name: "fresh", Type: "boolean", Description: "Fetch the hostel page again instead of serving the cached scrape"}}, Response: hostelList{}, Errors: map[string]string{"502": "The hostel page could not be scraped"}},
+	{Method: "GET", Path: "/api/hostels/:slug", ID: "getHostel", Tag: "hostels", Summary: "Functionaries of one hostel", Response: scrape.Hostel{}, Errors: map[string]string{"404": "Not found", "502": "The hostel page could not be scraped"}},
+
+	{Method: "POST", Path: "/api/hostels/allotment", ID: "allotHostelRooms", Tag: "hostels", Summary: "Allot rooms to the students of an uploaded CSV or XLSX file",
+		Query:  []openapi.Param{{Name: "dryRun", Type: "boolean", Description: "Report the allotment without saving it"}, allotmentFormatParam},
+		Upload: []string{"multipart/form-data"}, Response: allotmentResult{}, Errors: badRequest},
+	{Method: "POST", Path: "/api/hostels/allotment/rooms-from-excel", ID: "allotHostelRoomsFromExcel", Tag: "hostels", Summary: "Same as /api/hostels/allotment, at the path of the Node server",
+		Query:  []openapi.Param{{Name: "dryRun", Type: "boolean", Description: "Report the allotment without saving it"}, allotmentFormatParam},
+		Upload: []string{"multipart/form-data"}, Response: allotmentResult{}, Errors: badRequest},
+	{Method: "GET", Path: "/api/hostels/allotments/:id", ID: "getHostelAllotment", Tag: "hostels", Summary: "A saved allotment, as JSON or a CSV or XLSX download",
+		Query: []openapi.Param{allotmentFormatParam}, Response: allotmentResult{}, Errors: notFound},
+	{Method: "DELETE", Path: "/api/hostels/allotments/:id", ID: "deleteHostelAllotment", Tag: "hostels", Summary: "Delete a saved allotment, which is otherwise kept for ALLOTMENT_RETENTION; needs the server identity",
+		Status: "204", Errors: map[string]string{"403": "Missing server identity", "404": "Not found"}},
+
+	{Method: "GET", Path: "/api/announcements", ID: "listAnnouncements", Tag: "announcements", Summary: "Notices, tenders and events of nith.ac.in, newest first, as JSON or an RSS or Atom feed",
+		Query: []openapi.Param{
+			{Name: "kind", Enum: []string{scrape.KindNotice, scrape.KindTender, scrape.KindEvent}},
+			{Name: "since", Description: "Only items first seen after this date or RFC 3339 time"},
+			{Name: "q", Description: "Text in the title or summary"},
+			{Name: "limit", Type: "integer", Minimum: openapi.Num(0)},
+			{Name: "format", Enum: []string{"json", "rss", "atom"}},
+		},
+		Response: announcementList{}, Errors: badRequest},
+	{Method: "GET", Path: "/api/announcements/status", ID: "announcementStatus", Tag: "announcements", Summary: "Refresh state of every announcement page", Response: announcementStatus{}},
+	{Method: "POST", Path: "/api/announcements/refresh", ID: "refreshAnnouncements", Tag: "announcements", Summary: "Scrape the announcement pages again; needs the server identity",
+		Response: announcements.RefreshReport{}, Errors: map[string]string{"403": "Missing server identity", "409": "A refresh is already running", "502": "Every announcement page failed"}},
+
+	{Method: "GET", Path: "/api/v1/scrape", ID: "v1Scrape", Tag: "v1", Summary: "Version 1: fetch the result of a roll number",
+		Query: cacheParams, Response: v1.Student{}, Errors: map[string]string{"400": "Missing roll number", "500": "The result portal failed"}},
+	{Method: "POST", Path: "/api/v1/bulk-scrape", ID: "v1BulkScrape", Tag: "v1", Summary: "Version 1: fetch the results of several roll numbers",
+		Body: v1.BulkRequest{}, BodyRequired: []string{"rollNumbers"}, Response: []v1.ScrapeResult{}, Errors: badRequest},
+	{Method: "GET", Path: "/api/v1/results", ID: "v1ListResults", Tag: "v1", Summary: "Version 1: search stored results",
+		Query: storeQueryParams, Response: v1.Page{}, Errors: badRequest},
+	{Method: "GET", Path: "/api/v1/results/:rollNo", ID: "v1GetResult", Tag: "v1", Summary: "Version 1: stored result of a roll number", Response: v1.Student{}, Errors: notFound},
+}
+
+// Docs returns the OpenAPI document of the /api routes.
+func Docs() *openapi.Document {
+	docsOnce.Do(func() {
+		docs = openapi.Build(openapi.Info{
+			Title:       "NITH result server",
+			Version:     "1.0.0",
+			Description: "Scrapes, stores and analyses results of the NIT Hamirpur result portal. Routes under /api/v1 return the frozen version 1 payloads.",
+		}, apiRoutes)
+	})
+	return docs
+}
+
+var (
+	docsOnce sync.Once
+	docs     *openapi.Document
+)
+
+// v1Docs returns the OpenAPI document of the /api/v1 routes, built from the
+// same table as Docs.
+func v1Docs() *openapi.Document {
+	v1DocsOnce.Do(func() {
+		routes := []openapi.Route{}
+		for _, route := range apiRoutes {
+			if strings.HasPrefix(route.Path, "/api/v1/") {
+				routes = append(routes, route)
+			}
+		}
+		v1Document = openapi.Build(openapi.Info{
+			Title:       "Result API",
+			Version:     v1.Version,
+			Description: "Version 1 of the result API. Every response carries an API-Version: 1 header. Payload schemas are also published as JSON Schema at /api/v1/schema.json.",
+		}, routes)
+	})
+	return v1Document
+}
+
+var (
+	v1DocsOnce sync.Once
+	v1Document *openapi.Document
+)
+
+// validateRequest rejects requests whose query or JSON body contradict the
+// document before they reach a handler. Undocumented routes pass through.
+func validateRequest(c *fiber.Ctx) error {
+	op, _, ok := Docs().Find(c.Method(), c.Path())
+	if !ok {
+		return c.Next()
+	}
+	problems := Docs().Validate(op, func(name string) string { return c.Query(name) }, c.Get(fiber.HeaderContentType), c.Body())
+	if len(problems) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": strings.Join(problems, "; "), "problems": problems})
+	}
+	return c.Next()
+}
+
+// swaggerUI loads Swagger UI from a CDN and points it at /openapi.json.
+const swaggerUI = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>NITH result server API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5.17.14/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5.17.14/swagger-ui-bundle.js"></script>
+  <script>SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui" });</script>
+</body>
+</html>
+`
+
+// RegisterDocRoutes serves the OpenAPI document at /openapi.json and Swagger
+// UI at /docs.
+func RegisterDocRoutes(router fiber.Router) {
+	router.Get("/openapi.json", func(c *fiber.Ctx) error {
+		body, err := Docs().JSON()
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return c.Send(body)
+	})
+	router.Get("/docs", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		return c.SendString(swaggerUI)
+	})
+}
@@ -0,0 +1,70 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kanakkholwal/go-server/pkg/resultcache"
+	"golang.org/x/sync/singleflight"
+)
+
+// pageFetchTimeout bounds one scrape of an institute page, retries included.
+const pageFetchTimeout = time.Minute
+
+// pageCache keeps the latest scrape of an institute page for ttl. When a
+// refetch fails the previous scrape is served as stale rather than failing the
+// request; concurrent misses share one fetch.
+type pageCache[T any] struct {
+	ttl   time.Duration
+	fetch func(ctx context.Context) (T, error)
+
+	mu        sync.Mutex
+	value     T
+	fetchedAt time.Time
+	group     singleflight.Group
+}
+
+// get returns the cached page, fetching it when it is missing, older than
+// ttl or fresh is set, with the time it was fetched and a resultcache status.
+func (p *pageCache[T]) get(fresh bool) (T, time.Time, string, error) {
+	p.mu.Lock()
+	value, fetchedAt := p.value, p.fetchedAt
+	p.mu.Unlock()
+	if !fresh && !fetchedAt.IsZero() && time.Since(fetchedAt) < p.ttl {
+		return value, fetchedAt, resultcache.StatusHit, nil
+	}
+
+	_, err, _ := p.group.Do("page", func() (any, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), pageFetchTimeout)
+		defer cancel()
+		v, err := p.fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		p.mu.Lock()
+		p.value, p.fetchedAt = v, time.Now()
+		p.mu.Unlock()
+		return nil, nil
+	})
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err != nil {
+		if p.fetchedAt.IsZero() {
+			var zero T
+			return zero, time.Time{}, resultcache.StatusMiss, err
+		}
+		return p.value, p.fetchedAt, resultcache.StatusStale, nil
+	}
+	return p.value, p.fetchedAt, resultcache.StatusMiss, nil
+}
+
+// setPageHeaders reports how a cached page was served.
+func setPageHeaders(c *fiber.Ctx, fetchedAt time.Time, status string) {
+	c.Set(fiber.HeaderLastModified, fetchedAt.UTC().Format(http.TimeFormat))
+	c.Set(fiber.HeaderAge, strconv.Itoa(int(time.Since(fetchedAt).Seconds())))
+	c.Set("X-Cache", status)
+}
@@ -0,0 +1,39 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/kanakkholwal/go-server/pkg/planner"
+	"github.com/kanakkholwal/go-server/pkg/resultcache"
+)
+
+func registerPlannerRoutes(router fiber.Router) {
+	// what-if CGPI projections for a posted result or a roll number; a roll
+	// number is looked up in the stored results first and fetched through the
+	// result cache otherwise, like /scrape
+	router.Post("/planner", func(c *fiber.Ctx) error {
+		var req planner.Request
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid planner request"})
+		}
+		student := req.Result
+		if student == nil {
+			if req.RollNumber == "" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "rollNo or result is required"})
+			}
+			if stored, ok := resultStore.Get(req.RollNumber); ok {
+				student = &stored
+			} else {
+				entry, _, err := resultCache.Get(c.UserContext(), req.RollNumber, resultcache.Options{})
+				if err != nil {
+					return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+				}
+				student = &entry.Student
+			}
+		}
+		plan, err := planner.Build(student, req)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.JSON(plan)
+	})
+}
@@ -0,0 +1,108 @@
+package routes
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kanakkholwal/go-server/config"
+	"github.com/kanakkholwal/go-server/pkg/resultcache"
+	"github.com/kanakkholwal/go-server/pkg/scrape"
+)
+
+// resultCache sits in front of the result portal for /scrape.
+var resultCache *resultcache.Cache
+
+func initResultCache() {
+	cfg := config.Get()
+	cache, err := resultcache.New(cfg.ResultCacheTTL, cfg.ResultCacheSize, cfg.ResultCacheDir, scrape.GetResultByRollNumberContext)
+	if err != nil {
+		log.Printf("result cache: %v, keeping results in memory only", err)
+		cache, _ = resultcache.New(cfg.ResultCacheTTL, cfg.ResultCacheSize, "", scrape.GetResultByRollNumberContext)
+	}
+	resultCache = cache
+	// results of bulk scrapes warm the cache too
+	scrape.OnStudentScraped(resultCache.Put)
+}
+
+// cachedResult serves ?rollNo through the result cache. On failure it also
+// returns the HTTP status to respond with.
+func cachedResult(c *fiber.Ctx) (resultcache.Entry, string, int, error) {
+	rollNo := c.Query("rollNo")
+	if rollNo == "" {
+		return resultcache.Entry{}, "", fiber.StatusBadRequest, fmt.Errorf("rollNo query parameter is required")
+	}
+	opts, err := cacheOptions(c)
+	if err != nil {
+		return resultcache.Entry{}, "", fiber.StatusBadRequest, err
+	}
+	entry, status, err := resultCache.Get(c.UserContext(), rollNo, opts)
+	if err != nil {
+		return resultcache.Entry{}, "", fiber.StatusInternalServerError, err
+	}
+	return entry, status, fiber.StatusOK, nil
+}
+
+// cacheOptions reads the freshness the client asks for, either from the
+// Cache-Control request header or from the equivalent query parameters,
+// which take precedence:
+//
+//	Cache-Control: no-cache | max-age=60, stale-while-revalidate=600
+//	?fresh=true&maxAge=60&staleWhileRevalidate=600
+func cacheOptions(c *fiber.Ctx) (resultcache.Options, error) {
+	var opts resultcache.Options
+	for _, directive := range strings.Split(c.Get(fiber.HeaderCacheControl), ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(strings.ToLower(directive)), "=")
+		switch name {
+		case "no-cache", "no-store":
+			opts.Fresh = true
+		case "max-age":
+			if seconds, err := strconv.Atoi(value); err == nil {
+				opts.MaxAge = time.Duration(seconds) * time.Second
+				// max-age=0 asks for a result that is not cached at all
+				opts.Fresh = opts.Fresh || seconds == 0
+			}
+		case "stale-while-revalidate":
+			if seconds, err := strconv.Atoi(value); err == nil {
+				opts.StaleWhileRevalidate = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	if c.Query("fresh") != "" {
+		opts.Fresh = c.QueryBool("fresh")
+	}
+	for key, target := range map[string]*time.Duration{"maxAge": &opts.MaxAge, "staleWhileRevalidate": &opts.StaleWhileRevalidate} {
+		seconds, err := queryInt(c, key)
+		if err != nil {
+			return opts, err
+		}
+		if seconds < 0 {
+			return opts, fmt.Errorf("%s must not be negative", key)
+		}
+		if c.Query(key) != "" {
+			*target = time.Duration(seconds) * time.Second
+		}
+	}
+	return opts, nil
+}
+
+// standingETag versions a result with its standing, which changes with the
+// stored results as well as with the result itself.
+func standingETag(etag string, storeVersion uint64) string {
+	return fmt.Sprintf(`%s-standing-%d"`, strings.TrimSuffix(etag, `"`), storeVersion)
+}
+
+// setCacheHeaders describes the cached entry to the client. It reports true
+// when the client already has this version, in which case the caller should
+// answer 304 Not Modified.
+func setCacheHeaders(c *fiber.Ctx, entry resultcache.Entry, status string) bool {
+	c.Set(fiber.HeaderETag, entry.ETag)
+	c.Set(fiber.HeaderLastModified, entry.FetchedAt.Format(http.TimeFormat))
+	c.Set(fiber.HeaderAge, strconv.Itoa(int(entry.Age().Seconds())))
+	c.Set("X-Cache", status)
+	return c.Get(fiber.HeaderIfNoneMatch) == entry.ETag
+}
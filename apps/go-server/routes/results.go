@@ -3,12 +3,12 @@ package routes
 import (
 	"context"
 	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/kanakkholwal/go-server/pkg/analytics"
 	"github.com/kanakkholwal/go-server/pkg/scrape"
-	"github.com/kanakkholwal/go-server/utils"
+	"github.com/kanakkholwal/go-server/types"
 )
 
 type BulkRequest struct {
@@ -16,39 +16,59 @@ type BulkRequest struct {
 }
 
 func RegisterRoutes(router fiber.Router) {
+	scrape.OnStudentScraped(resultStore.Upsert)
+	scrape.OnStudentScraped(courseCatalog.Observe)
+	initResultCache()
+	router.Use(validateRequest)
+	registerV1Routes(router.Group("/v1"))
 
 	// Register the scrape route with query rollNo
 	router.Get("/scrape", func(c *fiber.Ctx) error {
-		rollNo := c.Query("rollNo")
-		if rollNo == "" {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "rollNo query parameter is required"})
-		}
-
-		result, err := scrape.GetResultByRollNumber(rollNo)
+		entry, status, code, err := cachedResult(c)
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+			return c.Status(code).JSON(fiber.Map{"error": err.Error()})
+		}
+		result := entry.Student
+		// ?standing=true positions the result among the stored results, so
+		// its version also depends on the store
+		standing := c.QueryBool("standing")
+		if standing {
+			entry.ETag = standingETag(entry.ETag, resultStore.Version())
+		}
+		if setCacheHeaders(c, entry, status) {
+			return c.SendStatus(fiber.StatusNotModified)
+		}
+		if standing {
+			standing := analytics.StudentStanding(&result, resultStore.Select(nil))
+			result.Standing = &standing
 		}
 
 		return c.JSON(result)
 	})
 
-	// generate roll numbers route
+	// generate roll numbers route, filtered by the cohort query parameters
 	router.Get("/generate-roll-numbers", func(c *fiber.Ctx) error {
-		batchYear := c.Query("batch")
-		if batchYear == "" {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "batchYear query parameter is required"})
-		}
-		// Convert batchYear to integer and should be year 2020 or greater and in YYYY format
-		batchYearInt, err := strconv.Atoi(batchYear)
-		if err != nil || batchYearInt < 2020 || batchYearInt > 2100 {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "batchYear should be a valid year in YYYY format and greater than or equal to 2020"})
+		if c.Query("batch") == "" && c.Query("include") == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "batch query parameter is required"})
 		}
-		rollNumbers := utils.GenRollNumbers(batchYearInt)
-		if len(rollNumbers) == 0 {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "No roll numbers generated for the given batch year"})
+		rollNumbers, status, err := resolveCohortQuery(c)
+		if err != nil {
+			return c.Status(status).JSON(fiber.Map{"error": err.Error()})
 		}
 		return c.JSON(rollNumbers)
 	})
+	// resolve a cohort selector posted as JSON
+	router.Post("/cohort", func(c *fiber.Ctx) error {
+		var sel types.CohortSelector
+		if err := c.BodyParser(&sel); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid cohort selector"})
+		}
+		rollNumbers, status, err := resolveCohort(sel)
+		if err != nil {
+			return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.JSON(fiber.Map{"count": len(rollNumbers), "rollNumbers": rollNumbers})
+	})
 	// bulk scrape
 	router.Post("/bulk-scrape", func(c *fiber.Ctx) error {
 		var req BulkRequest
@@ -63,27 +83,14 @@ func RegisterRoutes(router fiber.Router) {
 		return c.JSON(results)
 	})
 
-	// scrape all batch roll numbers
+	// scrape all batch roll numbers, optionally narrowed by the cohort query parameters
 	router.Post("/scrape-batch", func(c *fiber.Ctx) error {
-		batchYear := c.Query("batchYear")
-		if batchYear == "" {
+		if c.Query("batchYear") == "" {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "batchYear query parameter is required"})
 		}
-		// Convert batchYear to integer and should be year 2020 or greater and in YYYY format
-		// Convert batchYear to integer and should be year 2020 or greater and in YYYY format
-		batchYearInt, err := strconv.Atoi(batchYear)
-		if err != nil || batchYearInt < 2020 || batchYearInt > 2100 {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "batchYear should be a valid year in YYYY format and greater than or equal to 2020"})
-		}
-		rollNumbers := utils.GenRollNumbers(batchYearInt)
-		if len(rollNumbers) == 0 {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "No roll numbers generated for the given batch year"})
-		}
-
-		var req BulkRequest
-		req.RollNumbers = rollNumbers
-		if len(req.RollNumbers) == 0 {
-			return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input or empty rollNumbers list"})
+		rollNumbers, status, err := resolveCohortQuery(c)
+		if err != nil {
+			return c.Status(status).JSON(fiber.Map{"error": err.Error()})
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
@@ -92,27 +99,14 @@ func RegisterRoutes(router fiber.Router) {
 		results := scrape.ScrapeInBulk(ctx, rollNumbers, 30, 500*time.Millisecond)
 		return c.JSON(results)
 	})
-	// scrape all class roll numbers
+	// scrape all class roll numbers: one branch of one programme in a batch
 	router.Get("/scrape-class", func(c *fiber.Ctx) error {
-		batchYear := c.Query("batch")
-		if batchYear == "" {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "batchYear query parameter is required"})
+		if c.Query("batch") == "" || c.Query("branch") == "" || c.Query("programme") == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "batch, branch and programme query parameters are required"})
 		}
-		// Convert batchYear to integer and should be year 2020 or greater and in YYYY format
-		// Convert batchYear to integer and should be year 2020 or greater and in YYYY format
-		batchYearInt, err := strconv.Atoi(batchYear)
-		if err != nil || batchYearInt < 2020 || batchYearInt > 2100 {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "batchYear should be a valid year in YYYY format and greater than or equal to 2020"})
-		}
-		rollNumbers := utils.GenRollNumbers(batchYearInt)
-		if len(rollNumbers) == 0 {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "No roll numbers generated for the given batch year"})
-		}
-
-		var req BulkRequest
-		req.RollNumbers = rollNumbers
-		if len(req.RollNumbers) == 0 {
-			return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input or empty rollNumbers list"})
+		rollNumbers, status, err := resolveCohortQuery(c)
+		if err != nil {
+			return c.Status(status).JSON(fiber.Map{"error": err.Error()})
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
@@ -122,4 +116,17 @@ func RegisterRoutes(router fiber.Router) {
 		return c.JSON(results)
 	})
 
+	registerAcademicRoutes(router)
+	registerJobRoutes(router)
+	registerCourseRoutes(router)
+	registerAnalyticsRoutes(router)
+	registerStoreRoutes(router)
+	registerPlannerRoutes(router)
+	registerExportRoutes(router)
+	registerImportRoutes(router)
+	registerFacultyRoutes(router)
+	registerDepartmentRoutes(router)
+	registerHostelRoutes(router)
+	registerAllotmentRoutes(router)
+	registerAnnouncementRoutes(router)
 }
@@ -0,0 +1,131 @@
+package routes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kanakkholwal/go-server/constants"
+	"github.com/kanakkholwal/go-server/pkg/analytics"
+	"github.com/kanakkholwal/go-server/pkg/store"
+	"github.com/kanakkholwal/go-server/types"
+	"github.com/kanakkholwal/go-server/utils"
+)
+
+// resultStore holds every scraped result. It is in memory unless main
+// replaces it through UseStore.
+var resultStore store.Store = store.NewMemory()
+
+// UseStore replaces the result store and feeds the stored results to the
+// course catalogue. It must be called before RegisterRoutes.
+func UseStore(st store.Store) {
+	resultStore = st
+	for _, student := range st.Select(nil) {
+		courseCatalog.Observe(&student)
+	}
+}
+
+// storedCohort returns the stored students matching the cohort query
+// parameters. Unlike the scraping routes the batch is optional and defaults
+// to every batch.
+func storedCohort(c *fiber.Ctx) ([]types.StudentHtmlParsed, error) {
+	sel, err := cohortFromQuery(c)
+	if err != nil {
+		return nil, err
+	}
+	if len(sel.Batches) == 0 {
+		for year := constants.FirstBatchYear; year <= time.Now().Year(); year++ {
+			sel.Batches = append(sel.Batches, year)
+		}
+	}
+	match, err := utils.CohortMatcher(sel)
+	if err != nil {
+		return nil, err
+	}
+	return resultStore.Select(func(student *types.StudentHtmlParsed) bool {
+		return match(student.RollNumber)
+	}), nil
+}
+
+// storeQuery builds a store query from the query parameters:
+//
+//	?batch=2021,2022&branch=cs&programme=btech&minCgpi=8&maxCgpi=10&q=sharma&sort=-cgpi&page=2&limit=50
+func storeQuery(c *fiber.Ctx) (store.Query, error) {
+	q := store.Query{Name: c.Query("q"), Sort: c.Query("sort", store.SortRollNumber)}
+	for _, b := range splitList(c.Query("batch")) {
+		year, err := strconv.Atoi(b)
+		if err != nil {
+			return q, fmt.Errorf("batch %q should be a valid year in YYYY format", b)
+		}
+		q.Batches = append(q.Batches, year)
+	}
+	// branches and programmes are stored by display name
+	for _, b := range splitList(c.Query("branch")) {
+		if name, ok := utils.BranchDisplayName(b); ok {
+			b = name
+		}
+		q.Branches = append(q.Branches, b)
+	}
+	for _, p := range splitList(c.Query("programme")) {
+		if name, ok := utils.NormalizeProgramme(p); ok {
+			p = name
+		}
+		q.Programmes = append(q.Programmes, p)
+	}
+	for key, target := range map[string]**float64{"minCgpi": &q.MinCGPI, "maxCgpi": &q.MaxCGPI} {
+		if raw := c.Query(key); raw != "" {
+			v, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return q, fmt.Errorf("%s should be a number", key)
+			}
+			*target = &v
+		}
+	}
+	switch strings.TrimPrefix(q.Sort, "-") {
+	case store.SortRollNumber, store.SortName, store.SortCGPI, store.SortBatch:
+	default:
+		return q, fmt.Errorf("sort should be one of rollNo, name, cgpi or batch, optionally prefixed with -")
+	}
+	var err error
+	if q.Page, err = queryInt(c, "page"); err != nil {
+		return q, err
+	}
+	if q.Limit, err = queryInt(c, "limit"); err != nil {
+		return q, err
+	}
+	return q, nil
+}
+
+func registerStoreRoutes(router fiber.Router) {
+	// search the stored results, paginated
+	router.Get("/results", func(c *fiber.Ctx) error {
+		q, err := storeQuery(c)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.JSON(resultStore.Query(q))
+	})
+	// every stored version of a result, oldest first
+	router.Get("/results/:rollNo/history", func(c *fiber.Ctx) error {
+		history := resultStore.History(c.Params("rollNo"))
+		if len(history) == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "no stored result for this roll number"})
+		}
+		return c.JSON(history)
+	})
+	// stored result of a roll number, without contacting the result portal;
+	// ?standing=true adds percentiles and z-scores among the stored results
+	router.Get("/results/:rollNo", func(c *fiber.Ctx) error {
+		student, ok := resultStore.Get(c.Params("rollNo"))
+		if !ok {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "no stored result for this roll number"})
+		}
+		if c.QueryBool("standing") {
+			standing := analytics.StudentStanding(&student, resultStore.Select(nil))
+			student.Standing = &standing
+		}
+		return c.JSON(student)
+	})
+}
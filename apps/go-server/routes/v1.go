@@ -0,0 +1,72 @@
+package routes
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	v1 "github.com/kanakkholwal/go-server/pkg/api/v1"
+	"github.com/kanakkholwal/go-server/pkg/scrape"
+)
+
+// registerV1Routes serves the frozen version 1 payloads of pkg/api/v1. The
+// unversioned routes keep returning the internal types as they evolve.
+func registerV1Routes(router fiber.Router) {
+	router.Use(func(c *fiber.Ctx) error {
+		c.Set("API-Version", v1.Version)
+		return c.Next()
+	})
+
+	router.Get("/schema.json", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, "application/schema+json")
+		return c.Send(v1.Schema)
+	})
+	router.Get("/openapi.json", func(c *fiber.Ctx) error {
+		body, err := v1Docs().JSON()
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(v1.Error{Error: err.Error()})
+		}
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return c.Send(body)
+	})
+
+	router.Get("/scrape", func(c *fiber.Ctx) error {
+		entry, status, code, err := cachedResult(c)
+		if err != nil {
+			return c.Status(code).JSON(v1.Error{Error: err.Error()})
+		}
+		if setCacheHeaders(c, entry, status) {
+			return c.SendStatus(fiber.StatusNotModified)
+		}
+		return c.JSON(v1.FromStudent(entry.Student))
+	})
+
+	router.Post("/bulk-scrape", func(c *fiber.Ctx) error {
+		var req v1.BulkRequest
+		if err := c.BodyParser(&req); err != nil || len(req.RollNumbers) == 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(v1.Error{Error: "Invalid input or empty rollNumbers list"})
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		results := scrape.ScrapeInBulk(ctx, req.RollNumbers, 5, 500*time.Millisecond)
+		return c.JSON(v1.FromScrapeResults(results))
+	})
+
+	router.Get("/results", func(c *fiber.Ctx) error {
+		q, err := storeQuery(c)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(v1.Error{Error: err.Error()})
+		}
+		return c.JSON(v1.FromPage(resultStore.Query(q)))
+	})
+
+	router.Get("/results/:rollNo", func(c *fiber.Ctx) error {
+		student, ok := resultStore.Get(c.Params("rollNo"))
+		if !ok {
+			return c.Status(fiber.StatusNotFound).JSON(v1.Error{Error: "no stored result for this roll number"})
+		}
+		return c.JSON(v1.FromStudent(student))
+	})
+}
@@ -0,0 +1,42 @@
+package types
+
+// Academic status of a student derived from their scraped semesters.
+const (
+	StatusClear          = "clear"
+	StatusActiveBacklog  = "active_backlog"
+	StatusClearedBacklog = "cleared_backlog"
+)
+
+// BacklogCourse is a course a student received a failing grade in, together
+// with the later semester (if any) in which it was passed.
+type BacklogCourse struct {
+	Code         string   `json:"code"`
+	Name         string   `json:"name"`
+	Credit       int64    `json:"credit"`
+	FailedIn     []string `json:"failedIn"`
+	FailGrades   []string `json:"failGrades"`
+	Cleared      bool     `json:"cleared"`
+	ClearedIn    string   `json:"clearedIn,omitempty"`
+	ClearedGrade string   `json:"clearedGrade,omitempty"`
+}
+
+type AcademicStatus struct {
+	Status             string          `json:"status"`
+	ActiveBacklogs     int             `json:"activeBacklogs"`
+	ClearedBacklogs    int             `json:"clearedBacklogs"`
+	OutstandingCredits int64           `json:"outstandingCredits"`
+	Backlogs           []BacklogCourse `json:"backlogs"`
+}
+
+// BranchInference compares the branch encoded in a roll number with the branch
+// whose courses a student has actually been taking after the first year.
+type BranchInference struct {
+	OriginalBranch     string         `json:"originalBranch"`
+	OriginalBranchCode string         `json:"originalBranchCode"`
+	CurrentBranch      string         `json:"currentBranch"`
+	CurrentBranchCode  string         `json:"currentBranchCode"`
+	Confidence         float64        `json:"confidence"`
+	Changed            bool           `json:"changed"`
+	CoursesConsidered  int            `json:"coursesConsidered"`
+	PrefixCounts       map[string]int `json:"prefixCounts,omitempty"`
+}
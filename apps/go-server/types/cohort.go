@@ -0,0 +1,15 @@
+package types
+
+// CohortSelector describes a set of students by batch year, programme, branch
+// and roll serial range. Include and Exclude are explicit roll number lists
+// applied after the filters: included rolls are always part of the cohort,
+// excluded rolls never are.
+type CohortSelector struct {
+	Batches    []int    `json:"batches"`
+	Programmes []string `json:"programmes,omitempty"`
+	Branches   []string `json:"branches,omitempty"`
+	SerialFrom int      `json:"serialFrom,omitempty"`
+	SerialTo   int      `json:"serialTo,omitempty"`
+	Include    []string `json:"include,omitempty"`
+	Exclude    []string `json:"exclude,omitempty"`
+}
@@ -28,8 +28,17 @@ type StudentResultWithRanks struct {
 	Rank        Rank    `json:"rank"`
 }
 
+// Programme phases a semester can belong to. Dual degree students have
+// semesters in both; every other programme has a single phase.
+const (
+	PhaseBachelor = "bachelor"
+	PhaseMaster   = "master"
+)
+
 type SemesterResult struct {
 	SemesterNumber string          `json:"semester"`
+	Phase          string          `json:"phase,omitempty"`
+	Scheme         string          `json:"scheme,omitempty"`
 	SubjectResults []SubjectResult `json:"courses"`
 	SGPI           float64         `json:"sgpi"`
 	CGPI           float64         `json:"cgpi"`
@@ -46,6 +55,16 @@ type SubjectResult struct {
 	CGPI        float64 `json:"cgpi"`
 }
 
+// PhaseSummary is the standing of a student within one programme phase, as
+// reported by the result portal of that phase's scheme.
+type PhaseSummary struct {
+	Phase     string  `json:"phase"`
+	Scheme    string  `json:"scheme"`
+	CGPI      float64 `json:"cgpi"`
+	Credits   int64   `json:"credits"`
+	Semesters int     `json:"semesters"`
+}
+
 type StudentHtmlParsed struct {
 	RollNumber      string           `json:"rollNo"`
 	Name            string           `json:"name"`
@@ -55,4 +74,29 @@ type StudentHtmlParsed struct {
 	Programme       string           `json:"programme"`
 	Branch          string           `json:"branch"`
 	Batch           int              `json:"batch"`
+	Gender          string           `json:"gender,omitempty"`
+	Phases          []PhaseSummary   `json:"phases,omitempty"`
+	CGPIRule        string           `json:"cgpiRule,omitempty"`
+	AcademicStatus  *AcademicStatus  `json:"academicStatus,omitempty"`
+	BranchInfo      *BranchInference `json:"branchInfo,omitempty"`
+	QualityFlags    []QualityFlag    `json:"qualityFlags,omitempty"`
+	Standing        *Standing        `json:"standing,omitempty"`
+	Abnormal        bool             `json:"abnormal,omitempty"`
+	Warnings        []string         `json:"warnings,omitempty"`
+}
+
+// Severities of a QualityFlag. Records with at least one error flag are
+// considered abnormal.
+const (
+	SeverityWarning = "warning"
+	SeverityError   = "error"
+)
+
+// QualityFlag is one finding of the result validator.
+type QualityFlag struct {
+	Code     string `json:"code"`
+	Severity string `json:"severity"`
+	Semester string `json:"semester,omitempty"`
+	Course   string `json:"course,omitempty"`
+	Message  string `json:"message"`
 }
@@ -0,0 +1,36 @@
+package types
+
+// Position places one value of a student among the same value of their peers.
+// Percentile is the share of peers below the student (ties count half), on a
+// 0-100 scale; ZScore is measured in population standard deviations.
+type Position struct {
+	Rank       int64   `json:"rank"`
+	Peers      int     `json:"peers"`
+	Percentile float64 `json:"percentile"`
+	ZScore     float64 `json:"zScore"`
+}
+
+// ScopePositions holds a Position per peer group, matching the scopes of Rank:
+// class (batch, branch and programme), branch (batch and branch), batch and
+// the whole college.
+type ScopePositions struct {
+	Class   *Position `json:"class,omitempty"`
+	Branch  *Position `json:"branch,omitempty"`
+	Batch   *Position `json:"batch,omitempty"`
+	College *Position `json:"college,omitempty"`
+}
+
+// SemesterStanding positions a semester's SGPI, and from the second semester
+// on the change in SGPI since the previous semester, among peers.
+type SemesterStanding struct {
+	Semester    string          `json:"semester"`
+	SGPI        ScopePositions  `json:"sgpi"`
+	SGPIChange  *float64        `json:"sgpiChange,omitempty"`
+	Improvement *ScopePositions `json:"improvement,omitempty"`
+}
+
+type Standing struct {
+	Rank      Rank               `json:"rank"`
+	CGPI      ScopePositions     `json:"cgpi"`
+	Semesters []SemesterStanding `json:"semesters"`
+}
@@ -0,0 +1,243 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	constants "github.com/kanakkholwal/go-server/constants"
+	"github.com/kanakkholwal/go-server/types"
+)
+
+// cohortFilter is the validated, normalised form of a types.CohortSelector.
+type cohortFilter struct {
+	batches    []int
+	programmes map[string]bool
+	branches   map[string]bool
+	serialFrom int
+	serialTo   int
+	include    []string
+	exclude    map[string]bool
+}
+
+// NormalizeProgramme maps user input such as "btech", "B.Tech" or "dual" to
+// the programme names used as keys of constants.ProgrammeKeys.
+func NormalizeProgramme(programme string) (string, bool) {
+	needle := alphaNumLower(programme)
+	if needle == "" {
+		return "", false
+	}
+	if needle == "dual" || needle == "dd" {
+		needle = "dualdegree"
+	}
+	for name := range constants.ProgrammeKeys {
+		if alphaNumLower(name) == needle {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// NormalizeBranch maps a branch code ("cs"), roll key ("bcs") or department
+// code ("cse") to the two letter branch code used inside roll numbers.
+func NormalizeBranch(branch string) (string, bool) {
+	branch = strings.ToLower(strings.TrimSpace(branch))
+	if branch == "" {
+		return "", false
+	}
+	if _, ok := departmentForBranchCode(branch); ok {
+		return branch, true
+	}
+	if len(branch) == 3 && programmeForKey(branch) != "" {
+		return branch[1:], true
+	}
+	for _, department := range constants.DepartmentsList {
+		if department.Code == branch && len(department.RollKeys) > 0 {
+			return department.RollKeys[0][1:], true
+		}
+	}
+	return "", false
+}
+
+func compileCohort(sel types.CohortSelector) (*cohortFilter, error) {
+	if len(sel.Batches) == 0 && len(sel.Include) == 0 {
+		return nil, fmt.Errorf("at least one batch year is required")
+	}
+	filter := &cohortFilter{
+		serialFrom: sel.SerialFrom,
+		serialTo:   sel.SerialTo,
+		exclude:    map[string]bool{},
+	}
+	seenBatch := map[int]bool{}
+	for _, batch := range sel.Batches {
+		if batch < constants.FirstBatchYear || batch > constants.LastBatchYear {
+			return nil, fmt.Errorf("batch %d should be a valid year in YYYY format and greater than or equal to %d", batch, constants.FirstBatchYear)
+		}
+		if !seenBatch[batch] {
+			seenBatch[batch] = true
+			filter.batches = append(filter.batches, batch)
+		}
+	}
+	sort.Ints(filter.batches)
+
+	if len(sel.Programmes) > 0 {
+		filter.programmes = map[string]bool{}
+		for _, p := range sel.Programmes {
+			name, ok := NormalizeProgramme(p)
+			if !ok {
+				return nil, fmt.Errorf("unknown programme %q", p)
+			}
+			filter.programmes[name] = true
+		}
+	}
+	if len(sel.Branches) > 0 {
+		filter.branches = map[string]bool{}
+		for _, b := range sel.Branches {
+			code, ok := NormalizeBranch(b)
+			if !ok {
+				return nil, fmt.Errorf("unknown branch %q", b)
+			}
+			filter.branches[code] = true
+		}
+	}
+
+	if sel.SerialFrom < 0 || sel.SerialTo < 0 {
+		return nil, fmt.Errorf("serial range must not be negative")
+	}
+	// the serial is the three digit NNN of a roll number
+	if sel.SerialFrom > MaxRollSerial || sel.SerialTo > MaxRollSerial {
+		return nil, fmt.Errorf("serial range must not go above %d", MaxRollSerial)
+	}
+	if sel.SerialFrom > 0 && sel.SerialTo > 0 && sel.SerialFrom > sel.SerialTo {
+		return nil, fmt.Errorf("serialFrom (%d) is greater than serialTo (%d)", sel.SerialFrom, sel.SerialTo)
+	}
+
+	for _, roll := range sel.Exclude {
+		filter.exclude[strings.ToUpper(strings.TrimSpace(roll))] = true
+	}
+	for _, roll := range sel.Include {
+		info, err := ParseRollNumber(roll)
+		if err != nil {
+			return nil, err
+		}
+		filter.include = append(filter.include, info.RollNumber)
+	}
+	return filter, nil
+}
+
+// ResolveCohort expands a selector into the list of roll numbers it covers.
+// Serials run from 1 to constants.ThresholdForProgramme unless SerialFrom or
+// SerialTo narrow or widen the range. Excluded rolls win over included ones.
+// The output is deterministic: batch, programme, roll key, serial, then the
+// explicitly included rolls.
+func ResolveCohort(sel types.CohortSelector) ([]string, error) {
+	filter, err := compileCohort(sel)
+	if err != nil {
+		return nil, err
+	}
+
+	programmes := make([]string, 0, len(constants.ProgrammeKeys))
+	for programme := range constants.ProgrammeKeys {
+		programmes = append(programmes, programme)
+	}
+	sort.Strings(programmes)
+
+	seen := map[string]bool{}
+	rollNumbers := []string{}
+	add := func(roll string) {
+		if seen[roll] || filter.exclude[roll] {
+			return
+		}
+		seen[roll] = true
+		rollNumbers = append(rollNumbers, roll)
+	}
+
+	for _, batch := range filter.batches {
+		for _, programme := range programmes {
+			if filter.programmes != nil && !filter.programmes[programme] {
+				continue
+			}
+			from, to := 1, constants.ThresholdForProgramme[programme]
+			if filter.serialFrom > 0 {
+				from = filter.serialFrom
+			}
+			if filter.serialTo > 0 {
+				to = filter.serialTo
+			}
+			for _, key := range constants.ProgrammeKeys[programme] {
+				if filter.branches != nil && !filter.branches[key[1:]] {
+					continue
+				}
+				for i := from; i <= to; i++ {
+					add(FormatRollNumber(batch, key, i))
+				}
+			}
+		}
+	}
+	for _, roll := range filter.include {
+		add(roll)
+	}
+	return rollNumbers, nil
+}
+
+// CohortMatcher reports whether a roll number belongs to a cohort. Unlike
+// ResolveCohort it does not cap serials at the programme threshold, so it can
+// be used to filter already scraped or stored results.
+func CohortMatcher(sel types.CohortSelector) (func(rollNo string) bool, error) {
+	filter, err := compileCohort(sel)
+	if err != nil {
+		return nil, err
+	}
+	included := map[string]bool{}
+	for _, roll := range filter.include {
+		included[roll] = true
+	}
+	return func(rollNo string) bool {
+		roll := strings.ToUpper(strings.TrimSpace(rollNo))
+		if filter.exclude[roll] {
+			return false
+		}
+		if included[roll] {
+			return true
+		}
+		info, err := ParseRollNumber(roll)
+		if err != nil {
+			return false
+		}
+		if !containsInt(filter.batches, info.Batch) {
+			return false
+		}
+		if filter.programmes != nil && !filter.programmes[info.Programme] {
+			return false
+		}
+		if filter.branches != nil && !filter.branches[info.Branch] {
+			return false
+		}
+		if filter.serialFrom > 0 && info.Serial < filter.serialFrom {
+			return false
+		}
+		if filter.serialTo > 0 && info.Serial > filter.serialTo {
+			return false
+		}
+		return true
+	}, nil
+}
+
+func containsInt(list []int, v int) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+func alphaNumLower(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"slices"
+	"strings"
+
+	constants "github.com/kanakkholwal/go-server/constants"
+)
+
+// DepartmentByCode finds a department by its code ("cse"), ignoring case.
+func DepartmentByCode(code string) (constants.Department, bool) {
+	code = strings.TrimSpace(code)
+	for _, department := range constants.DepartmentsList {
+		if strings.EqualFold(department.Code, code) {
+			return department, true
+		}
+	}
+	return constants.Department{}, false
+}
+
+// DepartmentForRollNumber finds the department whose roll keys contain the
+// programme code of a roll number (e.g. "bcs" in 22BCS001).
+func DepartmentForRollNumber(rollNo string) (constants.Department, bool) {
+	rollNo = strings.ToLower(strings.TrimSpace(rollNo))
+	if len(rollNo) < 5 {
+		return constants.Department{}, false
+	}
+	key := rollNo[2:5]
+	for _, department := range constants.DepartmentsList {
+		if slices.Contains(department.RollKeys, key) {
+			return department, true
+		}
+	}
+	return constants.Department{}, false
+}
+
+// departmentForBranchCode finds the department of a two letter branch code
+// ("cs"), the roll keys without their programme letter.
+func departmentForBranchCode(code string) (constants.Department, bool) {
+	for _, department := range constants.DepartmentsList {
+		for _, key := range department.RollKeys {
+			if key[1:] == code {
+				return department, true
+			}
+		}
+	}
+	return constants.Department{}, false
+}
+
+// CoursePrefix returns the leading letters of a course code, e.g. "CS" for
+// "CS-101" or "CSD" for "CSD 211".
+func CoursePrefix(courseCode string) string {
+	courseCode = strings.ToUpper(strings.TrimSpace(courseCode))
+	end := 0
+	for end < len(courseCode) && courseCode[end] >= 'A' && courseCode[end] <= 'Z' {
+		end++
+	}
+	return courseCode[:end]
+}
+
+// DepartmentForCoursePrefix finds the department offering courses with the
+// given prefix. An exact match on CoursePrefix wins; otherwise the department
+// with the longest CoursePrefix that starts the given prefix is used.
+func DepartmentForCoursePrefix(prefix string) (constants.Department, bool) {
+	prefix = CoursePrefix(prefix)
+	if prefix == "" {
+		return constants.Department{}, false
+	}
+	var best constants.Department
+	found := false
+	for _, department := range constants.DepartmentsList {
+		if department.CoursePrefix == prefix {
+			return department, true
+		}
+		if strings.HasPrefix(prefix, department.CoursePrefix) && len(department.CoursePrefix) > len(best.CoursePrefix) {
+			best = department
+			found = true
+		}
+	}
+	return best, found
+}
+
+// BranchDisplayName resolves any branch spelling accepted by NormalizeBranch
+// ("cs", "bcs", "cse") or a full branch name to the branch name stored on
+// results, e.g. "Computer Science and Engineering".
+func BranchDisplayName(branch string) (string, bool) {
+	if code, ok := NormalizeBranch(branch); ok {
+		department, _ := departmentForBranchCode(code)
+		return department.DisplayName, true
+	}
+	for _, department := range constants.DepartmentsList {
+		if strings.EqualFold(department.DisplayName, strings.TrimSpace(branch)) {
+			return department.DisplayName, true
+		}
+	}
+	return "", false
+}
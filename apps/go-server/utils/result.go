@@ -6,6 +6,7 @@ import (
 	"time"
 
 	constants "github.com/kanakkholwal/go-server/constants"
+	"github.com/kanakkholwal/go-server/types"
 )
 
 // var thresholdForBranch = map[string]int{
@@ -23,76 +24,71 @@ import (
 // 	"dcs": 30,
 // }
 
+// GenRollNumbers returns every roll number of a batch across all programmes.
+// Roll number format: YYXXXNNN, where YY is the last two digits of the batch year, XXX is the programme code, and NNN is the roll number
+// Example: 20BCE001 for B.Tech Civil Engineering, 20DEC001 for Dual Degree Electronics and Communication Engineering
+// 20BAR001 for B.Arch Architecture, 20MCE001 for M.Tech Civil Engineering
 func GenRollNumbers(batchYear int) []string {
-	if batchYear < 2020 {
+	if batchYear < constants.FirstBatchYear {
 		return []string{}
 	}
-	var rollNumbers []string
-
-	for programme, codes := range constants.ProgrammeKeys {
-		threshold := constants.ThresholdForProgramme[programme]
-		for _, code := range codes {
-			for i := 1; i <= threshold; i++ {
-				// Generate roll number based on the batch year and programme
-				// Roll number format: YYXXXNNN, where YY is the last two digits of the batch year, XXX is the programme code, and NNN is the roll number
-				// Example: 20BCE001 for B.Tech Civil Engineering, 20DEC001 for Dual Degree Electronics and Communication Engineering
-				// 20BAR001 for B.Arch Architecture, 20MCE001 for M.Tech Civil Engineering
-				rollNumber := fmt.Sprintf("%02d%s%03d", batchYear%100, strings.ToUpper(code), i)
-				rollNumbers = append(rollNumbers, rollNumber)
-			}
-		}
+	rollNumbers, err := ResolveCohort(types.CohortSelector{Batches: []int{batchYear}})
+	if err != nil {
+		return []string{}
 	}
-
 	return rollNumbers
 }
 
+// GenRollNumbersForAll returns the roll numbers of every batch from
+// constants.FirstBatchYear up to the current year.
 func GenRollNumbersForAll() []string {
-
-	for i := 2020; i <= time.Now().Year(); i++ {
-		rollNumbers := GenRollNumbers(i)
-		if len(rollNumbers) > 0 {
-			return rollNumbers
-		}
+	batches := []int{}
+	for i := constants.FirstBatchYear; i <= time.Now().Year(); i++ {
+		batches = append(batches, i)
 	}
-	return []string{}
-
+	rollNumbers, err := ResolveCohort(types.CohortSelector{Batches: batches})
+	if err != nil {
+		return []string{}
+	}
+	return rollNumbers
 }
-func GenRollNumbersForClass(branch string, programme string) []string {
-	branch = strings.ToLower(branch)
-	programme = strings.Title(strings.ToLower(programme)) // Ensure it matches map keys like "B.Tech"
 
+// GenRollNumbersForClass returns the roll numbers of one branch of one
+// programme in a batch, e.g. (2022, "cs", "B.Tech").
+func GenRollNumbersForClass(batchYear int, branch string, programme string) []string {
 	if branch == "" || programme == "" {
 		return []string{}
 	}
-	if _, ok := constants.BranchCodesToNames[branch]; !ok {
-		return []string{}
-	}
-	progCodes, ok := constants.ProgrammeKeys[programme]
-	if !ok {
+	rollNumbers, err := ResolveCohort(types.CohortSelector{
+		Batches:    []int{batchYear},
+		Programmes: []string{programme},
+		Branches:   []string{branch},
+	})
+	if err != nil {
 		return []string{}
 	}
+	return rollNumbers
+}
 
-	rollNumbers := GenRollNumbers(time.Now().Year())
-	matching := []string{}
-
-	// check each generated rollNumber for matching programme + branch code
-	for _, roll := range rollNumbers {
-		for _, code := range progCodes {
-			if strings.Contains(roll, code) && strings.Contains(roll, branch) {
-				matching = append(matching, roll)
-				break
-			}
-		}
-	}
-
-	return matching
+// ResultSource is one result portal the semesters of a roll number are
+// published on, together with the programme phase those semesters belong to.
+type ResultSource struct {
+	URL    string
+	Scheme string
+	Phase  string
 }
 
-func GetUrlForRollNumber(rollNumber string, dualDegree bool) []string {
+// GetResultSources lists the result portals for a roll number in the order they
+// should be fetched. The first source is the primary one; dual degree students
+// get a second source for their master phase.
+func GetResultSources(rollNumber string, dualDegree bool) []ResultSource {
+	rollNumber = strings.ToLower(rollNumber)
+	if len(rollNumber) < 5 {
+		return []ResultSource{}
+	}
 	year := rollNumber[:2]
 	schema := "scheme"
-
-	urls := []string{}
+	phase := types.PhaseBachelor
 
 	isDualDegree := false
 	// Check if the roll number is for a dual degree | Explicitly check for combined results of both programmes
@@ -100,54 +96,54 @@ func GetUrlForRollNumber(rollNumber string, dualDegree bool) []string {
 	if strings.Contains(rollNumber, "dec") || strings.Contains(rollNumber, "dcs") {
 		isDualDegree = true
 	}
-	// Check if the roll number is valid
 
 	// identify the scheme based on the roll number using programmeKeys and schemeKeys
 	for programme, codes := range constants.ProgrammeKeys {
 		for _, code := range codes {
 			if strings.Contains(rollNumber, code) && !isDualDegree {
-				schema = constants.SchemeKeys[programme]
+				if s, ok := constants.SchemeKeys[programme]; ok {
+					schema = s
+				}
+				if programme == "M.Tech" || programme == "M.Arch" {
+					phase = types.PhaseMaster
+				}
 				break
 			}
 		}
 	}
-	urls = append(urls, fmt.Sprintf("http://results.nith.ac.in/%s%s/studentresult/result.asp", schema, year))
+	sources := []ResultSource{{
+		URL:    fmt.Sprintf("http://results.nith.ac.in/%s%s/studentresult/result.asp", schema, year),
+		Scheme: schema + year,
+		Phase:  phase,
+	}}
 	// Check if the roll number is for a dual degree
 	if isDualDegree || dualDegree {
 		// If the roll number is for a dual degree, append the dual degree URL
 		schema = constants.SchemeKeys["Dual Degree"]
-		urls = append(urls, fmt.Sprintf("http://results.nith.ac.in/%s%s/studentresult/result.asp", schema, year))
+		sources = append(sources, ResultSource{
+			URL:    fmt.Sprintf("http://results.nith.ac.in/%s%s/studentresult/result.asp", schema, year),
+			Scheme: schema + year,
+			Phase:  types.PhaseMaster,
+		})
 	}
+	return sources
+}
 
+func GetUrlForRollNumber(rollNumber string, dualDegree bool) []string {
+	urls := []string{}
+	for _, source := range GetResultSources(rollNumber, dualDegree) {
+		urls = append(urls, source.URL)
+	}
 	return urls
 }
 
+// DetermineDepartment returns the branch name results carry for the
+// department of a roll number, or "Unknown".
 func DetermineDepartment(rollNo string) string {
-	rollNo = strings.ToLower(rollNo)
-	switch {
-	case strings.Contains(rollNo, "bar"):
-		return "Architecture"
-	case strings.Contains(rollNo, "bce"):
-		return "Civil Engineering"
-	case strings.Contains(rollNo, "bme"):
-		return "Mechanical Engineering"
-	case strings.Contains(rollNo, "bms"):
-		return "Materials Science and Engineering"
-	case strings.Contains(rollNo, "bma"):
-		return "Mathematics and Computing"
-	case strings.Contains(rollNo, "bph"):
-		return "Engineering Physics"
-	case strings.Contains(rollNo, "bee"):
-		return "Electrical Engineering"
-	case strings.Contains(rollNo, "bec"), strings.Contains(rollNo, "dec"):
-		return "Electronics and Communication Engineering"
-	case strings.Contains(rollNo, "bcs"), strings.Contains(rollNo, "dcs"):
-		return "Computer Science and Engineering"
-	case strings.Contains(rollNo, "bch"):
-		return "Chemical Engineering"
-	default:
-		return "Unknown"
+	if department, ok := DepartmentForRollNumber(rollNo); ok {
+		return department.DisplayName
 	}
+	return "Unknown"
 }
 
 func DetermineProgramme(rollNo string) string {
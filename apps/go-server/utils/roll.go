@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	constants "github.com/kanakkholwal/go-server/constants"
+)
+
+// RollInfo is the decoded form of a roll number such as 21DCS001.
+type RollInfo struct {
+	RollNumber string `json:"rollNo"`
+	Batch      int    `json:"batch"`
+	RollKey    string `json:"rollKey"`
+	Programme  string `json:"programme"`
+	Branch     string `json:"branch"`
+	Serial     int    `json:"serial"`
+}
+
+// ParseRollNumber splits a roll number into batch year, roll key (programme
+// letter + branch code) and serial. The roll key must be one of
+// constants.ProgrammeKeys.
+func ParseRollNumber(rollNo string) (RollInfo, error) {
+	roll := strings.ToUpper(strings.TrimSpace(rollNo))
+	if len(roll) < 6 {
+		return RollInfo{}, fmt.Errorf("invalid roll number %q", rollNo)
+	}
+	year, err := strconv.Atoi(roll[:2])
+	if err != nil {
+		return RollInfo{}, fmt.Errorf("invalid batch in roll number %q", rollNo)
+	}
+	key := strings.ToLower(roll[2:5])
+	serial, err := strconv.Atoi(roll[5:])
+	if err != nil || serial < 0 {
+		return RollInfo{}, fmt.Errorf("invalid serial in roll number %q", rollNo)
+	}
+	programme := programmeForKey(key)
+	if programme == "" {
+		return RollInfo{}, fmt.Errorf("unknown programme code %q in roll number %q", key, rollNo)
+	}
+	return RollInfo{
+		RollNumber: roll,
+		Batch:      2000 + year,
+		RollKey:    key,
+		Programme:  programme,
+		Branch:     key[1:],
+		Serial:     serial,
+	}, nil
+}
+
+// MaxRollSerial is the largest serial a roll number can hold.
+const MaxRollSerial = 999
+
+// FormatRollNumber is the inverse of ParseRollNumber.
+// Roll number format: YYXXXNNN, where YY is the last two digits of the batch year,
+// XXX is the roll key and NNN is the serial.
+func FormatRollNumber(batchYear int, rollKey string, serial int) string {
+	return fmt.Sprintf("%02d%s%03d", batchYear%100, strings.ToUpper(rollKey), serial)
+}
+
+func programmeForKey(key string) string {
+	for programme, codes := range constants.ProgrammeKeys {
+		for _, code := range codes {
+			if code == key {
+				return programme
+			}
+		}
+	}
+	return ""
+}